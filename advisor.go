@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"yellowstone_evolution/engine"
+)
+
+// ===== ADVISOR =====
+//
+// After a target has resisted several attempts in a row, the advisor points at targets
+// the forecast rates better. It is a banner above the board that the player can
+// dismiss, never a dialog.
+
+const (
+	// adviseAfterFailures is how many failures in a row on one target prompt advice.
+	adviseAfterFailures = 3
+	adviceSuggestions   = 2
+)
+
+// RetryAdvice names the target being retried and better alternatives to it.
+type RetryAdvice struct {
+	Target       string
+	Failures     int
+	Alternatives []TargetForecast
+}
+
+// retryAdvice returns advice for the target with the longest failure streak, if it has
+// reached adviseAfterFailures, the player has not dismissed it, and something on the
+// board is expected to do better.
+func retryAdvice(state *engine.GameState) (RetryAdvice, bool) {
+	var advice RetryAdvice
+	for _, name := range engine.SortedAnimalNames(state) {
+		if n := state.FailStreak[name]; n > advice.Failures {
+			advice.Target, advice.Failures = name, n
+		}
+	}
+	if advice.Failures < adviseAfterFailures || state.AdviceDismissed[advice.Target] {
+		return advice, false
+	}
+	target := state.Animals[advice.Target]
+	if target == nil || !engine.IsCandidateTarget(state, target) {
+		return advice, false
+	}
+
+	current := forecastTarget(state, target)
+	for _, f := range forecastTargets(state) {
+		if f.Animal == target || f.Blocked != "" || f.Chance <= 0 {
+			continue
+		}
+		if current.Chance <= 0 || f.ScoreImpact > current.ScoreImpact {
+			advice.Alternatives = append(advice.Alternatives, f)
+		}
+	}
+	sort.SliceStable(advice.Alternatives, func(i, j int) bool {
+		return advice.Alternatives[i].ScoreImpact > advice.Alternatives[j].ScoreImpact
+	})
+	if len(advice.Alternatives) > adviceSuggestions {
+		advice.Alternatives = advice.Alternatives[:adviceSuggestions]
+	}
+	return advice, len(advice.Alternatives) > 0
+}
+
+func (a RetryAdvice) String() string {
+	var alts []string
+	for _, f := range a.Alternatives {
+		alts = append(alts, fmt.Sprintf("%s (%.0f%%, %+d expected)", f.Animal.Name, f.Chance*100, f.ScoreImpact))
+	}
+	return fmt.Sprintf("💡 %s has resisted %d times in a row. Better odds: %s.", a.Target, a.Failures, strings.Join(alts, ", "))
+}
+
+// adviceBanner is the dismissible hint above the board, or nil when there is no advice.
+func adviceBanner(state *engine.GameState, redraw func()) fyne.CanvasObject {
+	advice, ok := retryAdvice(state)
+	if !ok || engine.Blinded(state) {
+		return nil
+	}
+	text := newLabel(advice.String())
+	text.Wrapping = fyne.TextWrapWord
+	dismiss := newButton("Dismiss", inputs.Bind("dismiss-advice", func() {
+		state.AdviceDismissed[advice.Target] = true
+		redraw()
+	}))
+	return container.NewBorder(nil, nil, nil, dismiss, text)
+}
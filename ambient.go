@@ -0,0 +1,214 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ===== AMBIENT BACKGROUND =====
+
+const (
+	prefAmbientOff   = "ambientOff"
+	ambientSpores    = 40
+	ambientFrame     = 50 * time.Millisecond
+	parallaxMargin   = 16
+	sporeMaxDiameter = 6
+)
+
+// AmbientEffects drives the drifting spores of whichever background is on screen. It has
+// its own random source so cosmetic motion never disturbs the seeded game rolls.
+type AmbientEffects struct {
+	mu      sync.Mutex
+	off     bool
+	current *AmbientBackground
+	started bool
+	rng     *rand.Rand
+	// wake restarts the loop after it parked itself with the effects off.
+	wake chan struct{}
+}
+
+var ambient = &AmbientEffects{rng: rand.New(rand.NewSource(time.Now().UnixNano())), wake: make(chan struct{}, 1)}
+
+// SetEnabled is the performance toggle: off leaves a still background with no spores.
+func (a *AmbientEffects) SetEnabled(on bool) {
+	a.mu.Lock()
+	a.off = !on
+	cur := a.current
+	a.mu.Unlock()
+	if on {
+		a.resume()
+	}
+	if cur != nil {
+		cur.Refresh()
+	}
+}
+
+// resume wakes a parked loop; it never blocks.
+func (a *AmbientEffects) resume() {
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (a *AmbientEffects) Enabled() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return !a.off
+}
+
+// active reports whether spores should be drawn and moved right now.
+func (a *AmbientEffects) active() bool {
+	return a.Enabled() && !animations.ReducedMotion()
+}
+
+func (a *AmbientEffects) show(b *AmbientBackground) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.current = b
+	if a.started {
+		return
+	}
+	a.started = true
+	go a.loop()
+}
+
+func (a *AmbientEffects) loop() {
+	for sleepOrShutdown(ambientFrame) {
+		// With the effects off, park until they are turned back on rather than post
+		// idle frames to the UI thread.
+		if !a.active() {
+			select {
+			case <-a.wake:
+				continue
+			case <-shutdown.Done():
+				return
+			}
+		}
+		fyne.Do(func() {
+			a.mu.Lock()
+			cur := a.current
+			a.mu.Unlock()
+			if cur != nil && a.active() {
+				cur.step()
+				devHUD.countFrame()
+			}
+		})
+	}
+}
+
+type spore struct {
+	x, y, speed, sway float64
+	dot               *canvas.Circle
+}
+
+// AmbientBackground is the shared screen background: the park image with spores drifting
+// up over it and a slight parallax shift following the mouse.
+type AmbientBackground struct {
+	widget.BaseWidget
+	img    *canvas.Image
+	spores []*spore
+	// offset is the parallax shift, from -1 to 1 on each axis.
+	offsetX, offsetY float64
+	tick             float64
+}
+
+func NewAmbientBackground(img *canvas.Image) *AmbientBackground {
+	b := &AmbientBackground{img: img}
+	ambient.mu.Lock()
+	for i := 0; i < ambientSpores; i++ {
+		dot := canvas.NewCircle(color.NRGBA{R: 0xc8, G: 0xff, B: 0xa0, A: uint8(40 + ambient.rng.Intn(80))})
+		b.spores = append(b.spores, &spore{
+			x:     ambient.rng.Float64(),
+			y:     ambient.rng.Float64(),
+			speed: 0.0008 + ambient.rng.Float64()*0.0015,
+			sway:  ambient.rng.Float64() * 2 * math.Pi,
+			dot:   dot,
+		})
+	}
+	ambient.mu.Unlock()
+	b.ExtendBaseWidget(b)
+	ambient.show(b)
+	return b
+}
+
+func (b *AmbientBackground) step() {
+	b.tick++
+	for _, s := range b.spores {
+		s.y -= s.speed
+		if s.y < 0 {
+			s.y = 1
+		}
+	}
+	b.Refresh()
+}
+
+func (b *AmbientBackground) MouseIn(ev *desktop.MouseEvent) { b.MouseMoved(ev) }
+
+func (b *AmbientBackground) MouseMoved(ev *desktop.MouseEvent) {
+	size := b.Size()
+	if size.Width == 0 || size.Height == 0 || !ambient.active() {
+		return
+	}
+	b.offsetX = float64(ev.Position.X/size.Width)*2 - 1
+	b.offsetY = float64(ev.Position.Y/size.Height)*2 - 1
+	b.Refresh()
+}
+
+func (b *AmbientBackground) MouseOut() {}
+
+func (b *AmbientBackground) CreateRenderer() fyne.WidgetRenderer {
+	objects := []fyne.CanvasObject{b.img}
+	for _, s := range b.spores {
+		objects = append(objects, s.dot)
+	}
+	return &ambientRenderer{bg: b, objects: objects}
+}
+
+type ambientRenderer struct {
+	bg      *AmbientBackground
+	objects []fyne.CanvasObject
+}
+
+func (r *ambientRenderer) Layout(size fyne.Size) {
+	b := r.bg
+	active := ambient.active()
+
+	// The image overhangs the edges so the parallax shift never reveals a border.
+	shiftX, shiftY := float32(0), float32(0)
+	if active {
+		shiftX, shiftY = float32(-b.offsetX*parallaxMargin/2), float32(-b.offsetY*parallaxMargin/2)
+	}
+	b.img.Resize(fyne.NewSize(size.Width+2*parallaxMargin, size.Height+2*parallaxMargin))
+	b.img.Move(fyne.NewPos(-parallaxMargin+shiftX, -parallaxMargin+shiftY))
+
+	for i, s := range b.spores {
+		s.dot.Hidden = !active
+		d := float32(2 + i%sporeMaxDiameter)
+		x := float32(s.x)*size.Width + float32(math.Sin(b.tick/20+s.sway)*8) + shiftX*2
+		y := float32(s.y)*size.Height + shiftY*2
+		s.dot.Resize(fyne.NewSize(d, d))
+		s.dot.Move(fyne.NewPos(x, y))
+	}
+}
+
+func (r *ambientRenderer) MinSize() fyne.Size { return fyne.NewSize(0, 0) }
+
+func (r *ambientRenderer) Refresh() {
+	r.Layout(r.bg.Size())
+	for _, o := range r.objects {
+		o.Refresh()
+	}
+}
+
+func (r *ambientRenderer) Objects() []fyne.CanvasObject { return r.objects }
+
+func (r *ambientRenderer) Destroy() {}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// ===== ANIMATION SYSTEM =====
+
+const prefReducedMotion = "reducedMotion"
+
+// AnimationFrame is one step of an animation: wait Delay, then Apply on the UI thread.
+type AnimationFrame struct {
+	Delay time.Duration
+	Apply func()
+}
+
+// Animator is the single place motion reaches the screen. With reduced motion on, frames
+// are skipped and the transition becomes a static hold of the same length, so flows that
+// wait on an animation (and recorded inputs) keep their timing.
+type Animator struct {
+	mu      sync.Mutex
+	reduced bool
+}
+
+var animations = &Animator{}
+
+func (an *Animator) SetReducedMotion(on bool) {
+	an.mu.Lock()
+	an.reduced = on
+	an.mu.Unlock()
+	if !on {
+		ambient.resume()
+	}
+}
+
+// ReducedMotion reports the player's preference, which lite mode always overrides.
+func (an *Animator) ReducedMotion() bool {
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	return an.reduced || liteMode
+}
+
+// Run plays frames in order, holds for hold, then calls after on the UI thread.
+// It gives up silently if the game shuts down mid-animation.
+func (an *Animator) Run(frames []AnimationFrame, hold time.Duration, after func()) {
+	reduced := an.ReducedMotion()
+
+	go func() {
+		if reduced {
+			total := hold
+			for _, f := range frames {
+				total += f.Delay
+			}
+			if !sleepOrShutdown(total) {
+				return
+			}
+		} else {
+			for _, f := range frames {
+				if !sleepOrShutdown(f.Delay) {
+					return
+				}
+				fyne.Do(f.Apply)
+			}
+			if !sleepOrShutdown(hold) {
+				return
+			}
+		}
+		fyne.Do(after)
+	}()
+}
+
+// sleepOrShutdown waits for d and reports false if the game shut down first.
+func sleepOrShutdown(d time.Duration) bool {
+	select {
+	case <-shutdown.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
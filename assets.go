@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== ASSET CHECKS =====
+
+// runAssets implements the "assets" subcommand:
+//
+//	assets check [--map file]
+//	assets migrate [--map file] [--dry-run]
+//
+// check lists every animal whose portrait or custom sounds are missing, and exits 1 if
+// any are. migrate renames them to slugged file names; see slug.go.
+func runAssets(args []string) int {
+	if len(args) > 0 && args[0] == "migrate" {
+		return runMigrate(args[1:])
+	}
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: assets check|migrate [--map file]")
+		return 2
+	}
+	fs := flag.NewFlagSet("assets check", flag.ContinueOnError)
+	mapFlag := fs.String("map", "", "map file to check (default: the user's copy, else the bundled map)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	mapPath := initDataDirs()
+	if *mapFlag != "" {
+		mapPath = *mapFlag
+	}
+	state := engine.NewGameState(mapPath)
+
+	missing := missingAssets(state)
+	for _, m := range missing {
+		fmt.Printf("%-24s %-8s %s\n", m.Animal, m.Kind, m.Path)
+	}
+	if len(missing) > 0 {
+		fmt.Printf("%d missing asset(s) in %s\n", len(missing), mapPath)
+		return 1
+	}
+	fmt.Printf("All assets present for %d animals in %s\n", len(state.Animals), mapPath)
+	return 0
+}
+
+// MissingAsset is an image or sound a map refers to that is not on disk. Animal is "-"
+// for the game's own sounds.
+type MissingAsset struct {
+	Animal string `json:"animal"`
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+}
+
+// missingAssets lists every portrait and sound of state's map that is missing.
+func missingAssets(state *engine.GameState) []MissingAsset {
+	var missing []MissingAsset
+	report := func(animal, kind, path string) {
+		if !engine.FileExists(path) {
+			missing = append(missing, MissingAsset{animal, kind, path})
+		}
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		report(name, "image", a.GetImagePath())
+		if a.Sounds.Evolve != "" {
+			report(name, "evolve", resolveAsset(a.Sounds.Evolve))
+		}
+		if a.Sounds.Resist != "" {
+			report(name, "resist", resolveAsset(a.Sounds.Resist))
+		}
+	}
+	for _, path := range []string{"sfx/click.mp3", defaultSuccessSound, defaultFailSound, "sfx/victory.mp3", "music/background.mp3"} {
+		report("-", "sound", path)
+	}
+	return missing
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/mp3"
+	"github.com/faiface/beep/speaker"
+	"yellowstone_evolution/engine"
+)
+
+// ===== AUDIO MANAGER =====
+
+const (
+	defaultSuccessSound = "sfx/success.mp3"
+	defaultFailSound    = "sfx/fail.mp3"
+)
+
+// AudioManager decodes sound effects once into memory so playback never touches disk.
+type AudioManager struct {
+	mu      sync.Mutex
+	rate    beep.SampleRate
+	buffers map[string]*beep.Buffer
+}
+
+var audio = &AudioManager{buffers: map[string]*beep.Buffer{}}
+
+// SetSampleRate records the speaker's rate so buffers are resampled to match it.
+func (m *AudioManager) SetSampleRate(rate beep.SampleRate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rate = rate
+}
+
+func (m *AudioManager) buffer(path string) (*beep.Buffer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if buf, ok := m.buffers[path]; ok {
+		return buf, nil
+	}
+
+	f, err := os.Open(resolveAsset(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	streamer, format, err := mp3.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	defer streamer.Close()
+
+	var s beep.Streamer = streamer
+	if m.rate != 0 && m.rate != format.SampleRate {
+		s = beep.Resample(4, format.SampleRate, m.rate, streamer)
+		format.SampleRate = m.rate
+	}
+
+	buf := beep.NewBuffer(format)
+	buf.Append(s)
+	m.buffers[path] = buf
+	return buf, nil
+}
+
+// Preload decodes the given files ahead of time, skipping any that fail.
+func (m *AudioManager) Preload(paths ...string) {
+	if liteMode {
+		return
+	}
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if _, err := m.buffer(p); err != nil {
+			slog.Warn("sfx preload failed", "err", err)
+		}
+	}
+}
+
+// PreloadAnimals preloads every per-animal cue referenced by the map.
+func (m *AudioManager) PreloadAnimals(animals map[string]*engine.Animal) {
+	for _, a := range animals {
+		m.Preload(a.Sounds.Evolve, a.Sounds.Resist)
+	}
+}
+
+func (m *AudioManager) Play(path string) {
+	if liteMode {
+		return
+	}
+	buf, err := m.buffer(path)
+	if err != nil {
+		slog.Warn("sfx failed", "err", err)
+		return
+	}
+	speaker.Play(buf.Streamer(0, buf.Len()))
+}
+
+// ===== OUTCOME STINGERS =====
+
+// Stinger is the cue for one outcome magnitude. Pitch above 1 plays the file faster and
+// higher; 0 means unchanged.
+type Stinger struct {
+	Path    string
+	Pitch   float64
+	Caption string
+}
+
+// outcomeStingers escalate from a soft chime for a sideways infection to the fanfare
+// for winning. A close call is the failure sound, slowed down.
+var outcomeStingers = map[engine.Magnitude]Stinger{
+	engine.MagnitudeMiss:          {Path: defaultFailSound},
+	engine.MagnitudeCloseCall:     {Path: defaultFailSound, Pitch: 0.7, Caption: "[close call — low rumble]"},
+	engine.MagnitudeDefenseBroken: {Path: defaultSuccessSound, Pitch: 0.9, Caption: "[defense cracks]"},
+	engine.MagnitudeSameLevel:     {Path: defaultSuccessSound, Pitch: 0.8, Caption: "[soft chime]"},
+	engine.MagnitudeEvolution:     {Path: defaultSuccessSound, Pitch: 1.2, Caption: "[rising chime]"},
+	engine.MagnitudeApex:          {Path: "sfx/victory.mp3"},
+}
+
+// PlayPitched plays path at pitch times its normal speed.
+func (m *AudioManager) PlayPitched(path string, pitch float64) {
+	if liteMode {
+		return
+	}
+	if pitch == 0 || pitch == 1 {
+		m.Play(path)
+		return
+	}
+	buf, err := m.buffer(path)
+	if err != nil {
+		slog.Warn("sfx failed", "err", err)
+		return
+	}
+	speaker.Play(beep.ResampleRatio(4, pitch, buf.Streamer(0, buf.Len())))
+}
+
+// attachStingers plays a stinger for every outcome event. A map's own per-animal cue
+// replaces the stinger for its magnitude.
+func attachStingers() {
+	engine.Events.Subscribe(engine.EventOutcome, func(ev engine.Event) {
+		if ev.Bot {
+			return
+		}
+		if ev.Sound != "" {
+			PlaySoundEffect(ev.Sound)
+			return
+		}
+		st := outcomeStingers[ev.Magnitude]
+		audio.PlayPitched(st.Path, st.Pitch)
+		engine.Events.Publish(engine.Event{Kind: engine.EventSound, Detail: st.Path, Caption: st.Caption})
+	})
+}
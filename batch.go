@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== BATCH MODE =====
+//
+// Batch mode plays a scripted sequence of actions without opening a window:
+//
+//	start Mouse; infect Fox; skip; note Wolf is nocturnal; infect Wolf
+//
+// "burst" arms the once-per-run mutation burst for the next attempt, or disarms it.
+// With --passive-spread, "priority <name>" marks a priority target for tonight's spread,
+// or unmarks it.
+// With --mutations, "upgrades" lists the mutation points and upgrades, and
+// "upgrade <name>" buys one.
+// With --practice, "branch <name>", "revert <name>" and "undo" are also accepted.
+//
+// Actions are separated by semicolons or newlines, and lines starting with # are
+// comments. The result is printed to stdout as JSON.
+
+type BatchStep struct {
+	Action string `json:"action"`
+	Result string `json:"result"`
+	Detail string `json:"detail,omitempty"`
+	Day    int    `json:"day"`
+	Phase  string `json:"phase"`
+}
+
+type BatchSummary struct {
+	Seed     int64                `json:"seed"`
+	Stamp    engine.Stamp         `json:"stamp"`
+	Rules    engine.RulesConfig   `json:"rules"`
+	Won      bool                 `json:"won"`
+	Lost     bool                 `json:"lost,omitempty"`
+	Host     string               `json:"host"`
+	Level    int                  `json:"level"`
+	Day      int                  `json:"day"`
+	Attempts int                  `json:"attempts"`
+	Score    int                  `json:"score"`
+	Bound    *ScoreBound          `json:"score_bound,omitempty"`
+	Medal    string               `json:"medal,omitempty"`
+	Steps    []BatchStep          `json:"steps"`
+	Journal  []engine.JournalNote `json:"journal,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// runBatch plays the script at path ("-" for stdin) and returns the process exit code:
+// 0 when the script ran to completion, 1 when it could not be read or contained an
+// invalid action.
+func runBatch(path string, seed int64, mapPath string, rules engine.RulesConfig) int {
+	state := engine.NewGameState(mapPath)
+	if err := engine.CheckStarters(state); err != nil {
+		fmt.Fprintln(os.Stderr, "Batch error:", err)
+		return 1
+	}
+	state.Rules = rules
+	engine.SeedRun(state, seed)
+	return runBatchOn(path, state, false)
+}
+
+// runBatchOn plays the script on state, which may be a run already in progress. With
+// save set, the run is saved afterwards if it is still going, and the save dropped if
+// it is over.
+func runBatchOn(path string, state *engine.GameState, save bool) int {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Batch error:", err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	script, err := io.ReadAll(r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Batch error:", err)
+		return 1
+	}
+
+	summary := playBatch(state, parseBatchScript(string(script)))
+	summary.Seed = state.Seed
+	summary.Stamp = engine.CurrentStamp(state.MapPath)
+	summary.Rules = state.Rules
+	if save {
+		if summary.Won || summary.Lost {
+			ClearSave()
+		} else if err := SaveGame(state); err != nil {
+			fmt.Fprintln(os.Stderr, "Batch error: game not saved:", err)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(summary)
+
+	if summary.Error != "" {
+		return 1
+	}
+	return 0
+}
+
+func parseBatchScript(script string) []string {
+	var actions []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, action := range strings.Split(line, ";") {
+			if action = strings.TrimSpace(action); action != "" {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+func playBatch(state *engine.GameState, actions []string) BatchSummary {
+	var summary BatchSummary
+	won, lost := false, false
+
+	for _, action := range actions {
+		if won || lost {
+			break
+		}
+
+		verb, arg, _ := strings.Cut(action, " ")
+		arg = strings.TrimSpace(arg)
+		step := BatchStep{Action: action}
+
+		switch strings.ToLower(verb) {
+		case "start":
+			if state.PlayerName != "" {
+				summary.Error = fmt.Sprintf("%q: a starter was already chosen", action)
+				break
+			}
+			a, err := findAnimal(state, arg)
+			if err != nil {
+				summary.Error = fmt.Sprintf("%q: %v", action, err)
+				break
+			}
+			if err := engine.ChooseStarter(state, a); err != nil {
+				step.Result = "rejected"
+				step.Detail = err.Error()
+			} else {
+				step.Result = "started"
+				step.Detail = a.Name
+			}
+
+		case "infect":
+			if state.PlayerName == "" {
+				summary.Error = fmt.Sprintf("%q: no starter chosen yet", action)
+				break
+			}
+			a, err := findAnimal(state, arg)
+			if err != nil {
+				summary.Error = fmt.Sprintf("%q: %v", action, err)
+				break
+			}
+			engine.RememberUndo(state)
+			out := engine.AttemptInfection(state, a)
+			step.Result = out.Kind.String()
+			switch out.Kind {
+			case engine.OutcomeUnavailable:
+				step.Detail = out.Reason
+			case engine.OutcomeDefenseBroken:
+				step.Detail = out.Phase.Name
+			case engine.OutcomeResisted, engine.OutcomeRedHerring:
+				step.Detail = out.Consequence
+			case engine.OutcomeInfected, engine.OutcomeLatched:
+				step.Detail = a.Name
+			}
+			if out.Crit != "" {
+				step.Detail = strings.TrimSpace(step.Detail + " " + out.Crit)
+			}
+			won, lost = out.Won, out.Lost
+
+		case "burst":
+			if state.PlayerName == "" {
+				summary.Error = fmt.Sprintf("%q: no starter chosen yet", action)
+				break
+			}
+			if err := engine.ToggleBurst(state); err != nil {
+				step.Result = "rejected"
+				step.Detail = err.Error()
+				break
+			}
+			step.Result = "disarmed"
+			if state.BurstArmed {
+				step.Result = "armed"
+			}
+
+		case "priority":
+			if state.PlayerName == "" {
+				summary.Error = fmt.Sprintf("%q: no starter chosen yet", action)
+				break
+			}
+			a, err := findAnimal(state, arg)
+			if err != nil {
+				summary.Error = fmt.Sprintf("%q: %v", action, err)
+				break
+			}
+			if err := engine.TogglePriority(state, a); err != nil {
+				step.Result = "rejected"
+				step.Detail = err.Error()
+				break
+			}
+			step.Result = "unmarked"
+			if state.Priorities[a.Name] {
+				step.Result = "marked"
+			}
+			step.Detail = a.Name
+
+		case "upgrades":
+			if !state.Rules.Mutations {
+				summary.Error = fmt.Sprintf("%q: %v", action, engine.ErrMutationsOff)
+				break
+			}
+			step.Result = "listed"
+			step.Detail = mutationMenu(state)
+
+		case "upgrade":
+			if state.PlayerName == "" {
+				summary.Error = fmt.Sprintf("%q: no starter chosen yet", action)
+				break
+			}
+			u, err := engine.FindUpgrade(arg)
+			if err != nil {
+				summary.Error = fmt.Sprintf("%q: %v", action, err)
+				break
+			}
+			if err := engine.BuyUpgrade(state, u); err != nil {
+				step.Result = "rejected"
+				step.Detail = err.Error()
+				break
+			}
+			step.Result = "evolved"
+			step.Detail = upgradeLine(state, u)
+
+		case "note":
+			engine.AddJournalNote(state, arg)
+			step.Result = "noted"
+
+		case "skip", "wait":
+			if state.PlayerName == "" {
+				summary.Error = fmt.Sprintf("%q: no starter chosen yet", action)
+				break
+			}
+			engine.RememberUndo(state)
+			won, lost = engine.WaitOut(state)
+			step.Result = "waited"
+
+		case "branch", "revert", "undo":
+			if !state.Rules.Practice {
+				summary.Error = fmt.Sprintf("%q: only available in practice mode", action)
+				break
+			}
+			switch strings.ToLower(verb) {
+			case "branch":
+				engine.SaveBranch(state, arg)
+				step.Result = "branched"
+			case "revert":
+				if !engine.RevertToBranch(state, arg) {
+					summary.Error = fmt.Sprintf("%q: no branch named %q", action, arg)
+					break
+				}
+				step.Result = "reverted"
+			case "undo":
+				if state.Undo == nil {
+					step.Result = "rejected"
+					step.Detail = "nothing to undo"
+					break
+				}
+				state.Undo.Restore(state)
+				state.Undo = nil
+				step.Result = "undone"
+			}
+
+		default:
+			summary.Error = fmt.Sprintf("%q: unknown action", action)
+		}
+
+		if summary.Error != "" {
+			break
+		}
+		step.Day = state.CurrentDay
+		step.Phase = state.Phase.String()
+		summary.Steps = append(summary.Steps, step)
+	}
+
+	summary.Won = won
+	summary.Lost = lost
+	summary.Host = state.PlayerName
+	if host := state.Animals[state.PlayerName]; host != nil {
+		summary.Level = host.Level
+	}
+	summary.Day = state.CurrentDay
+	summary.Attempts = state.Stats.Attempts
+	summary.Score = engine.CalculateScore(state)
+	summary.Journal = state.Journal
+	if best, ok := scoreBound(state); ok && won {
+		summary.Bound = &best
+	}
+	if won {
+		summary.Medal = engine.MedalFor(state, summary.Score)
+	}
+	return summary
+}
+
+// findAnimal resolves a loosely typed name: an exact match wins, then a unique match on
+// a whole word ("Wolf" → "Gray Wolf"), then a unique substring match.
+func findAnimal(state *engine.GameState, query string) (*engine.Animal, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil, fmt.Errorf("missing animal name")
+	}
+
+	var byWord, bySubstring []*engine.Animal
+	for _, name := range engine.SortedAnimalNames(state) {
+		lower := strings.ToLower(name)
+		if lower == q {
+			return state.Animals[name], nil
+		}
+		for _, word := range strings.Fields(lower) {
+			if word == q {
+				byWord = append(byWord, state.Animals[name])
+				break
+			}
+		}
+		if strings.Contains(lower, q) {
+			bySubstring = append(bySubstring, state.Animals[name])
+		}
+	}
+
+	for _, matches := range [][]*engine.Animal{byWord, bySubstring} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			names := make([]string, len(matches))
+			for i, a := range matches {
+				names[i] = a.Name
+			}
+			return nil, fmt.Errorf("%q is ambiguous: %s", query, strings.Join(names, ", "))
+		}
+	}
+	return nil, fmt.Errorf("no animal named %q", query)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== BOARD VIEW SETTINGS =====
+
+const (
+	prefListView    = "boardListView"
+	prefGridColumns = "boardGridColumns"
+	prefListSort    = "boardListSort"
+
+	defaultGridColumns = 3
+)
+
+var gridColumnOptions = []string{"2", "3", "4", "5"}
+
+// targetRow is one line of the compact list view.
+type targetRow struct {
+	animal *engine.Animal
+	chance float64
+	// chanceText is chance as the board may show it; see ChanceText.
+	chanceText string
+	status     string
+	action     *widget.Button
+}
+
+func gridColumns(app fyne.App) int {
+	return app.Preferences().IntWithFallback(prefGridColumns, defaultGridColumns)
+}
+
+// boardViewControls switches between the card grid and the list, and sets grid density.
+// Both are remembered in the app preferences.
+func boardViewControls(app fyne.App, redraw func()) fyne.CanvasObject {
+	prefs := app.Preferences()
+	listView := prefs.Bool(prefListView)
+
+	label := "☰ List view"
+	if listView {
+		label = "▦ Card view"
+	}
+	toggle := newButton(label, func() {
+		prefs.SetBool(prefListView, !listView)
+		redraw()
+	})
+	if listView {
+		return toggle
+	}
+
+	density := widget.NewSelect(gridColumnOptions, nil)
+	density.SetSelected(strconv.Itoa(gridColumns(app)))
+	density.OnChanged = func(s string) {
+		n, _ := strconv.Atoi(s)
+		prefs.SetInt(prefGridColumns, n)
+		redraw()
+	}
+	return container.NewHBox(toggle, newLabel("Columns:"), density)
+}
+
+// ===== ADAPTIVE CARD BOARD =====
+//
+// When only a handful of animals are on offer, the card board shows them as large
+// featured cards with room for detail; past featuredLimit it falls back to the dense
+// grid. Each card is built by a function that is told which form it is drawn in.
+
+const (
+	featuredLimit     = 3
+	cardImageSize     = 160
+	featuredImageSize = 260
+)
+
+// boardCard builds one card, as a featured card when featured is set.
+type boardCard func(featured bool) fyne.CanvasObject
+
+// adaptiveBoard lays out cards as featured cards, or in columns when there are too
+// many to feature.
+func adaptiveBoard(columns int, cards []boardCard) fyne.CanvasObject {
+	featured := len(cards) > 0 && len(cards) <= featuredLimit
+	objs := make([]fyne.CanvasObject, len(cards))
+	for i, card := range cards {
+		objs[i] = card(featured)
+	}
+	if featured {
+		columns = len(objs)
+	}
+	return container.NewGridWithColumns(columns, objs...)
+}
+
+func portraitSize(featured bool) float32 {
+	if featured {
+		return featuredImageSize
+	}
+	return cardImageSize
+}
+
+// featuredDetail is the extra text a featured card shows for a, e.g.
+// "Level 3 · Terrestrial · Nocturnal · Lamar Valley".
+func featuredDetail(a *engine.Animal) fyne.CanvasObject {
+	parts := []string{fmt.Sprintf("Level %d", a.Level)}
+	for _, s := range []string{a.Mobility, a.ActivityPeriod, a.Location} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	l := newLabel(strings.Join(parts, " · "))
+	l.Alignment = fyne.TextAlignCenter
+	l.Wrapping = fyne.TextWrapWord
+	return l
+}
+
+// targetTable lays rows out as a table sorted by the remembered column; tapping a
+// column heading sorts by it.
+func targetTable(app fyne.App, rows []targetRow, redraw func()) fyne.CanvasObject {
+	prefs := app.Preferences()
+	by := prefs.StringWithFallback(prefListSort, "Name")
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch by {
+		case "Level":
+			return a.animal.Level > b.animal.Level
+		case "Chance":
+			return a.chance > b.chance
+		case "Status":
+			return a.status < b.status
+		default:
+			return a.animal.Name < b.animal.Name
+		}
+	})
+
+	heading := func(col string) fyne.CanvasObject {
+		text := col
+		if col == by {
+			text += " ▾"
+		}
+		b := newButton(text, func() {
+			prefs.SetString(prefListSort, col)
+			redraw()
+		})
+		b.Importance = widget.LowImportance
+		return b
+	}
+
+	cells := []fyne.CanvasObject{heading("Name"), heading("Level"), heading("Chance"), heading("Status"), newLabel("")}
+	for _, r := range rows {
+		cells = append(cells,
+			newLabel(r.animal.Name),
+			newLabel(strconv.Itoa(r.animal.Level)),
+			newLabel(r.chanceText),
+			newLabel(r.status),
+			r.action,
+		)
+	}
+	return container.NewGridWithColumns(5, cells...)
+}
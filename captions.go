@@ -0,0 +1,112 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== AUDIO CAPTIONS =====
+
+const (
+	prefCaptions    = "captions"
+	captionDuration = 2500 * time.Millisecond
+)
+
+// soundCaptions describes the bundled audio cues. An empty caption means the cue only
+// echoes something already on screen (a click) and is not captioned. Other files are
+// captioned from their name, so "sfx/wolf_howl.mp3" becomes "[wolf howl]".
+var soundCaptions = map[string]string{
+	"sfx/click.mp3":        "",
+	defaultSuccessSound:    "[success chime]",
+	defaultFailSound:       "[failure buzz]",
+	"sfx/victory.mp3":      "[victory fanfare]",
+	"music/background.mp3": "[ominous music plays]",
+}
+
+func captionFor(path string) string {
+	if c, ok := soundCaptions[path]; ok {
+		return c
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return "[" + strings.NewReplacer("_", " ", "-", " ").Replace(name) + "]"
+}
+
+// Captioner shows a short toast at the bottom of the window for every sound event.
+type Captioner struct {
+	mu      sync.Mutex
+	enabled bool
+	win     fyne.Window
+	current *widget.PopUp
+}
+
+var captions = &Captioner{}
+
+// Attach subscribes the captioner to sound events, drawing its toasts on win.
+func (c *Captioner) Attach(win fyne.Window) {
+	c.mu.Lock()
+	c.win = win
+	c.mu.Unlock()
+	engine.Events.Subscribe(engine.EventSound, func(ev engine.Event) {
+		text := ev.Caption
+		if text == "" {
+			text = captionFor(ev.Detail)
+		}
+		if text != "" {
+			c.Show(text)
+		}
+	})
+}
+
+func (c *Captioner) SetEnabled(on bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enabled = on
+}
+
+func (c *Captioner) Enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enabled
+}
+
+// Show replaces any caption on screen with text for captionDuration.
+func (c *Captioner) Show(text string) {
+	c.mu.Lock()
+	win, on := c.win, c.enabled
+	c.mu.Unlock()
+	if !on || win == nil {
+		return
+	}
+
+	fyne.Do(func() {
+		label := widget.NewLabelWithStyle(text, fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+		pop := widget.NewPopUp(label, win.Canvas())
+		size := pop.MinSize()
+		canvasSize := win.Canvas().Size()
+		pop.ShowAtPosition(fyne.NewPos((canvasSize.Width-size.Width)/2, canvasSize.Height-size.Height-24))
+
+		c.mu.Lock()
+		if c.current != nil {
+			c.current.Hide()
+		}
+		c.current = pop
+		c.mu.Unlock()
+
+		time.AfterFunc(captionDuration, func() {
+			fyne.Do(func() {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				pop.Hide()
+				if c.current == pop {
+					c.current = nil
+				}
+			})
+		})
+	})
+}
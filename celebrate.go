@@ -0,0 +1,143 @@
+package main
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ===== CELEBRATION =====
+
+const (
+	confettiPieces = 120
+	confettiFrames = 75
+	confettiFrame  = 33 * time.Millisecond
+	// countUpFrames is how many steps the score takes to count up from zero.
+	countUpFrames = 40
+)
+
+var confettiColors = []color.NRGBA{
+	{R: 0xff, G: 0x4d, B: 0x4d, A: 0xff},
+	{R: 0xff, G: 0xd2, B: 0x3f, A: 0xff},
+	{R: 0x5c, G: 0xe1, B: 0x7a, A: 0xff},
+	{R: 0x4d, G: 0xa6, B: 0xff, A: 0xff},
+	{R: 0xd0, G: 0x7a, B: 0xff, A: 0xff},
+}
+
+type confettiPiece struct {
+	x, y, vx, vy float64
+	rect         *canvas.Rectangle
+}
+
+// Confetti is a burst of falling paper pieces laid over a screen. It only moves through
+// the animation system, so reduced motion shows none of it.
+type Confetti struct {
+	widget.BaseWidget
+	pieces []*confettiPiece
+}
+
+func NewConfetti() *Confetti {
+	c := &Confetti{}
+	ambient.mu.Lock()
+	for i := 0; i < confettiPieces; i++ {
+		col := confettiColors[i%len(confettiColors)]
+		rect := canvas.NewRectangle(col)
+		rect.Hidden = true
+		c.pieces = append(c.pieces, &confettiPiece{
+			x:    ambient.rng.Float64(),
+			y:    -ambient.rng.Float64() * 0.5,
+			vx:   (ambient.rng.Float64() - 0.5) * 0.004,
+			vy:   0.008 + ambient.rng.Float64()*0.012,
+			rect: rect,
+		})
+	}
+	ambient.mu.Unlock()
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *Confetti) step() {
+	for _, p := range c.pieces {
+		p.x += p.vx
+		p.y += p.vy
+		p.rect.Hidden = false
+	}
+	c.Refresh()
+	devHUD.countFrame()
+}
+
+func (c *Confetti) clear() {
+	for _, p := range c.pieces {
+		p.rect.Hidden = true
+	}
+	c.Refresh()
+}
+
+// frames is the burst as animation frames.
+func (c *Confetti) frames() []AnimationFrame {
+	out := make([]AnimationFrame, confettiFrames)
+	for i := range out {
+		out[i] = AnimationFrame{Delay: confettiFrame, Apply: c.step}
+	}
+	return out
+}
+
+func (c *Confetti) CreateRenderer() fyne.WidgetRenderer {
+	objects := make([]fyne.CanvasObject, len(c.pieces))
+	for i, p := range c.pieces {
+		objects[i] = p.rect
+	}
+	return &confettiRenderer{c: c, objects: objects}
+}
+
+type confettiRenderer struct {
+	c       *Confetti
+	objects []fyne.CanvasObject
+}
+
+func (r *confettiRenderer) Layout(size fyne.Size) {
+	for i, p := range r.c.pieces {
+		w := float32(6 + i%3*2)
+		p.rect.Resize(fyne.NewSize(w, w/2))
+		p.rect.Move(fyne.NewPos(float32(p.x)*size.Width, float32(p.y)*size.Height))
+	}
+}
+
+func (r *confettiRenderer) MinSize() fyne.Size { return fyne.NewSize(0, 0) }
+
+func (r *confettiRenderer) Refresh() {
+	r.Layout(r.c.Size())
+	for _, o := range r.objects {
+		o.Refresh()
+	}
+}
+
+func (r *confettiRenderer) Objects() []fyne.CanvasObject { return r.objects }
+
+func (r *confettiRenderer) Destroy() {}
+
+// celebrate plays the win screen's burst and counts show up to score. show is called
+// with each intermediate score; with reduced motion only the final one, at once.
+func celebrate(confetti *Confetti, score int, show func(int)) {
+	if animations.ReducedMotion() {
+		show(score)
+		return
+	}
+	frames := confetti.frames()
+	for i := 0; i < countUpFrames && i < len(frames); i++ {
+		n := score * (i + 1) / countUpFrames
+		step := frames[i].Apply
+		frames[i].Apply = func() {
+			step()
+			show(n)
+		}
+	}
+	show(0)
+	animations.Run(frames, 0, func() {
+		confetti.clear()
+		show(score)
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ===== CLI COMMAND =====
+
+// runCLI implements the "cli" subcommand:
+//
+//	cli [rule flags] <script|->
+//
+// It plays the script like --batch and prints the result as JSON. With --resume the
+// script continues the saved game instead of starting a new run, and the run is saved
+// again afterwards.
+func runCLI(args []string) int {
+	fs := flag.NewFlagSet("cli", flag.ContinueOnError)
+	rules := ruleFlags(fs)
+	setupLogging := logFlags(fs)
+	mapFlag := fs.String("map", "", "map file to play (default: the user's copy, else the bundled map)")
+	seedFlag := fs.Int64("seed", 0, "seed the run so it rolls the same way every time (default: time-based); ignored with --resume")
+	resume := fs.Bool("resume", false, "continue the saved game, from the window or an earlier --resume, and save it again afterwards")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: cli [flags] <script|->")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	mapPath := initDataDirs()
+	setupLogging()
+	if *mapFlag != "" {
+		mapPath = *mapFlag
+	}
+	if *resume {
+		state, err := LoadGame()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "CLI error:", err)
+			return 1
+		}
+		return runBatchOn(fs.Arg(0), state, true)
+	}
+	r, err := rules()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "CLI error:", err)
+		return 1
+	}
+
+	seed := *seedFlag
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return runBatch(fs.Arg(0), seed, mapPath, r)
+}
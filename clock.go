@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"yellowstone_evolution/engine"
+)
+
+// ===== GAME CLOCK =====
+
+// showInformation is dialog.ShowInformation with the game clock stopped while it is open.
+func showInformation(state *engine.GameState, title, message string, win fyne.Window) {
+	showInformationContent(state, title, newLocalizedText(message, fyne.TextAlignLeading), win)
+}
+
+// showInformationContent is showInformation with a prepared body, e.g. flavor text.
+func showInformationContent(state *engine.GameState, title string, content fyne.CanvasObject, win fyne.Window) {
+	state.Stats.Clock.Pause()
+	d := dialog.NewCustom(plain(title), lang.L("OK"), content, win)
+	d.SetOnClosed(func() {
+		inputs.Record("dismiss")
+		state.Stats.Clock.Resume()
+	})
+	inputs.Handle("dismiss", d.Hide)
+	d.Show()
+}
+
+func showPauseDialog(state *engine.GameState, win fyne.Window) {
+	state.Stats.Clock.Pause()
+	var d dialog.Dialog
+	report := newButton("🐞 Report a problem…", func() {
+		d.Hide()
+		showFeedbackDialog(state, win)
+	})
+	d = dialog.NewCustom(plain("⏸ Paused"), "Resume", container.NewVBox(newLocalizedText("The outbreak waits for you.", fyne.TextAlignCenter), container.NewCenter(report)), win)
+	d.SetOnClosed(func() {
+		inputs.Record("dismiss")
+		state.Stats.Clock.Resume()
+	})
+	inputs.Handle("dismiss", d.Hide)
+	d.Show()
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== SUBCOMMANDS =====
+//
+// Everything ships as one binary:
+//
+//	rawr [gui] [flags]           play in a window (the default)
+//	rawr cli [flags] <script>    play a scripted run and print JSON
+//	rawr simulate [flags]        play many runs with a simple bot
+//	rawr plan ...                evaluate a proposed route
+//	rawr mapdiff old new         compare two maps
+//	rawr assets check            list missing images and sounds
+//	rawr assets migrate          rename images and sounds to slugged file names
+//	rawr lint map.json           run every map check for map authors
+//	rawr report map.json         write an HTML report on a map for sharing
+//	rawr import --inat-place ID  scaffold a map from a real species list
+//	rawr rules [flags]           print the rules of a run
+//	rawr scores [flags]          list the high scores
+//	rawr replay <replay.json>    step through a recorded run
+//	rawr update [--check]        install the latest release
+//
+// Flags that shape a run (rules, loadouts) are shared by every command that plays one.
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"gui", "play in a window (the default)", runGUI},
+		{"cli", "play a scripted run without a window and print the result as JSON", runCLI},
+		{"simulate", "play many seeded runs with a simple bot and summarize them", runSimulate},
+		{"plan", "evaluate a proposed route", runPlan},
+		{"mapdiff", "compare two maps", runMapDiff},
+		{"assets", "check that every animal has its image and sounds, or rename them to slugs", runAssets},
+		{"import", "scaffold a new map from an iNaturalist place or GBIF species list", runImport},
+		{"lint", "check a map for errors, missing assets and facts, and estimate its difficulty", runLint},
+		{"report", "write an HTML difficulty report on a map for sharing", runReport},
+		{"rules", "print the active ruleset in plain words", runRules},
+		{"scores", "list the best won runs, by map", runScores},
+		{"replay", "step through a recorded run, board by board", runReplay},
+		{"update", "check for a newer release and install it", runUpdate},
+		{"help", "list commands", runHelp},
+	}
+}
+
+// runCommand dispatches to a subcommand. Bare flags, or no arguments at all, start the
+// GUI so existing shortcuts and scripts keep working.
+func runCommand(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runGUI(args)
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	runHelp(nil)
+	return 2
+}
+
+func runHelp([]string) int {
+	fmt.Fprintln(os.Stderr, "usage: rawr <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun a command with -h for its flags.")
+	return 0
+}
+
+// ruleFlags registers the run-shaping flags on fs and returns a function that builds
+// the RulesConfig once fs has been parsed.
+func ruleFlags(fs *flag.FlagSet) func() (engine.RulesConfig, error) {
+	mercyRule := fs.Bool("mercy", false, "mercy rule: the first infection attempt always succeeds, at a score penalty")
+	outbreak := fs.Int("outbreak", 0, "outbreak victory: win by infecting this percentage of non-red-herring animals instead of reaching the apex")
+	budget := fs.Int("budget", 0, "infection budget: the run ends when this many attempts are spent without winning")
+	cooldown := fs.Bool("cooldown", false, "retry cooldown: a target that resists cannot be retried until the next day")
+	wildGenetics := fs.Bool("wild-genetics", false, "perturb animal stats within the map's genetics ranges each run")
+	contactWeights := fs.Bool("contact-weights", false, "contact graph mode: scale chances by the strength of the target's contacts with infected animals")
+	practice := fs.Bool("practice", false, "practice mode: undo and branch points, unranked")
+	twoStage := fs.Bool("two-stage", false, "two-stage infection: latch onto a host, then take it over on a later day")
+	passiveSpread := fs.Bool("passive-spread", false, "passive spread: the virus spreads along contacts each morning, and priority targets can be marked instead of attempting")
+	mutations := fs.Bool("mutations", false, "mutations: infections earn points to spend on virus upgrades between days")
+	immunity := fs.Bool("immunity", false, "immune response: infected animals other than the host may recover each morning and become immune")
+	cursesFlag := fs.Bool("curses", false, "curses: an attempt on a red herring hides the chances for a day or weakens the virus for two")
+	weekly := fs.Bool("weekly", false, "play this week's mutation, an extra chance modifier that changes every ISO week")
+	loadout := fs.String("loadout", "", "start with the options of this saved loadout")
+
+	return func() (engine.RulesConfig, error) {
+		if *loadout != "" {
+			l, ok := findLoadout(*loadout)
+			if !ok {
+				return engine.RulesConfig{}, fmt.Errorf("no saved loadout named %q", *loadout)
+			}
+			return l.Rules, nil
+		}
+		rules := engine.RulesConfig{MercyRule: *mercyRule, OutbreakPercent: *outbreak, WildGenetics: *wildGenetics, AttemptBudget: *budget, RetryCooldown: *cooldown, ContactWeighted: *contactWeights, Practice: *practice, TwoStage: *twoStage, PassiveSpread: *passiveSpread, Mutations: *mutations, Immunity: *immunity, Curses: *cursesFlag}
+		if *weekly {
+			loadWeeklyFeed()
+			rules.Weekly = weeklyFor(time.Now())
+		}
+		return rules, nil
+	}
+}
+
+// initDataDirs locates the per-user directories and returns the map to play.
+func initDataDirs() string {
+	dirs, err := defaultDataDirs()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "No user data directory, using bundled files only:", err)
+	}
+	dataDirs = dirs
+	return dataDirs.Find(dataDirs.Maps, engine.DefaultMapPath)
+}
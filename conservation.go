@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"yellowstone_evolution/engine"
+)
+
+// ===== CONSERVATION STATUS =====
+
+// encounteredSpecies lists the animals the run touched: patient zero and every animal
+// attempted or infected, including any that later died, in name order.
+func encounteredSpecies(state *engine.GameState) []string {
+	seen := map[string]bool{}
+	if state.Starter != "" {
+		seen[state.Starter] = true
+	}
+	for name := range state.LastInteraction {
+		seen[name] = true
+	}
+	for _, t := range state.Transmissions {
+		seen[t.To] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// conservationLines describes each encountered species, the most at risk first.
+func conservationLines(state *engine.GameState) []string {
+	rank := func(code string) int {
+		for i, cat := range engine.IUCNCategories {
+			if cat.Code == code {
+				return i
+			}
+		}
+		return -1
+	}
+	names := encounteredSpecies(state)
+	sort.SliceStable(names, func(i, j int) bool {
+		return rank(state.Conservation[names[i]].Status) > rank(state.Conservation[names[j]].Status)
+	})
+
+	var lines []string
+	for _, name := range names {
+		info, ok := state.Conservation[name]
+		if !ok {
+			lines = append(lines, name+" — no conservation data", "")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s) — %s", name, info.Scientific, info.StatusName()))
+		if info.Fact != "" {
+			lines = append(lines, "   "+info.Fact)
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// conservationButton opens the conservation summary of the finished run.
+func conservationButton(state *engine.GameState, win fyne.Window) fyne.CanvasObject {
+	return newButton("🌍 Conservation", inputs.Bind("conservation", func() {
+		text := newLabel(strings.Join(conservationLines(state), "\n"))
+		text.Wrapping = fyne.TextWrapWord
+		note := newLabel("Statuses are IUCN Red List categories. Learn more at iucnredlist.org.")
+		scroll := container.NewVScroll(text)
+		scroll.SetMinSize(fyne.NewSize(560, 360))
+		showInformationContent(state, "🌍 The Animals You Met", container.NewBorder(nil, note, nil, nil, scroll), win)
+	}))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== PASS AND PLAY =====
+
+// scoutedNote shows the player on turn what they know of a in a pass-and-play run.
+func scoutedNote(state *engine.GameState, a *engine.Animal) fyne.CanvasObject {
+	if engine.PassAndPlayOf(state) == nil {
+		return layout.NewSpacer()
+	}
+	note, ok := engine.KnownInteraction(state, a.Name)
+	if !ok {
+		return layout.NewSpacer()
+	}
+	if strings.HasSuffix(note, ": red herring") {
+		return newLabel("🚫 You found a red herring")
+	}
+	return newLabel("📝 " + note)
+}
+
+// createPassScreen covers the board between turns.
+func createPassScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+	autosave(state)
+
+	pp := engine.PassAndPlayOf(state)
+	next := engine.PlayerLabel(pp.Turn)
+
+	title := canvas.NewText(plain("🤝 Pass the device to "+next), color.White)
+	title.TextSize = 36
+	title.Alignment = fyne.TextAlignCenter
+
+	hint := newLabel(fmt.Sprintf("Only %s should look at the screen now. Your notes stay private: tell each other what you found, or don't.", next))
+	hint.Alignment = fyne.TextAlignCenter
+	hint.Wrapping = fyne.TextWrapWord
+
+	reveal := newButton("👀 I'm "+next+" — show my board", inputs.Bind("reveal", func() {
+		pp.Passing = false
+		win.SetContent(createGameScreen(app, win, state))
+	}))
+	reveal.Importance = widget.HighImportance
+
+	return NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewCenter(container.NewVBox(title, container.NewGridWrap(fyne.NewSize(520, 80), hint), container.NewCenter(reveal)))))
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== CUSTOM MAPS =====
+
+func mainMenu(app fyne.App, win fyne.Window, state *engine.GameState) *fyne.MainMenu {
+	return fyne.NewMainMenu(
+		fyne.NewMenu("File",
+			fyne.NewMenuItem("Load custom map…", func() { pickCustomMap(app, win, state) }),
+		),
+		fyne.NewMenu("View",
+			fyne.NewMenuItem("Developer HUD (F3)", devHUD.Toggle),
+		),
+		fyne.NewMenu("Help",
+			fyne.NewMenuItem("Rules", func() { win.SetContent(createRulesScreen(app, win, state)) }),
+			fyne.NewMenuItem("Check for updates…", func() { checkForUpdates(win) }),
+			fyne.NewMenuItem("Save log file…", func() { saveLogFile(win) }),
+			fyne.NewMenuItem("Report a problem…", func() { showFeedbackDialog(state, win) }),
+		),
+	)
+}
+
+// validateMap checks that path is a map the game can be won on, so a bad file is
+// rejected before it replaces the running one.
+func validateMap(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("not a map file: %w", err)
+	}
+
+	cfg := engine.LoadMapConfig(path)
+	if _, err := engine.CompileModifiers(cfg.Modifiers); err != nil {
+		return err
+	}
+
+	state := engine.NewGameState(path)
+	if cfg.Script != "" {
+		if _, err := engine.LoadMapScript(state, engine.ScriptPath(path, cfg.Script)); err != nil {
+			return fmt.Errorf("map script: %w", err)
+		}
+	}
+	if len(state.Animals) == 0 {
+		return fmt.Errorf("no animals found under \"Level…\" keys")
+	}
+	if err := engine.CheckStarters(state); err != nil {
+		return err
+	}
+	an := analyzeMap(state)
+	for lvl := engine.StarterLevel(state); lvl <= an.MaxLevel; lvl++ {
+		if an.Hosts[lvl] == 0 {
+			return fmt.Errorf("level %d has no animal that can be infected", lvl)
+		}
+	}
+	return nil
+}
+
+func pickCustomMap(app fyne.App, win fyne.Window, state *engine.GameState) {
+	open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if r == nil {
+			return
+		}
+		path := r.URI().Path()
+		r.Close()
+
+		if err := validateMap(path); err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", filepath.Base(path), err), win)
+			return
+		}
+
+		dialog.ShowConfirm("Custom Assets",
+			"Use a folder of images and red herring facts for this map?\nOtherwise the bundled ones are used.",
+			func(yes bool) {
+				if !yes {
+					swapMap(app, win, state, path, "")
+					return
+				}
+				dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+					if err != nil {
+						dialog.ShowError(err, win)
+						return
+					}
+					assets := ""
+					if dir != nil {
+						assets = dir.Path()
+					}
+					swapMap(app, win, state, path, assets)
+				}, win)
+			}, win)
+	}, win)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	if dir, err := storage.ListerForURI(storage.NewFileURI(dataDirs.Maps)); err == nil {
+		open.SetLocation(dir)
+	}
+	open.Show()
+}
+
+// swapMap abandons the current run and returns to the intro screen on the map at path.
+// assets, if set, is a folder that may hold a png/ directory of portraits and a
+// red_herring_facts.json and a conservation_status.json.
+func swapMap(app fyne.App, win fyne.Window, state *engine.GameState, path, assets string) {
+	next := engine.NewGameState(path)
+	next.Rules = state.Rules
+	engine.SeedRun(next, state.Seed)
+
+	useEcosystem(next)
+	if assets != "" {
+		useAssets(next, assets)
+	}
+
+	audio.PreloadAnimals(next.Animals)
+	win.SetMainMenu(mainMenu(app, win, next))
+	win.SetContent(createIntroScreen(app, win, next))
+}
+
+// factsPath is the red herring facts file the current map's facts were loaded from.
+var factsPath = engine.RedHerringFactsPath
+
+// useAssets points state at the images, red herring facts and conservation data in the
+// assets folder, or back at the bundled ones when assets is "".
+func useAssets(state *engine.GameState, assets string) {
+	engine.ImageDir = engine.DefaultImageDir
+	factsPath = engine.RedHerringFactsPath
+	if assets == "" {
+		return
+	}
+	engine.ImageDir = assets
+	if info, err := os.Stat(filepath.Join(assets, "png")); err == nil && info.IsDir() {
+		engine.ImageDir = filepath.Join(assets, "png")
+	}
+	if facts := filepath.Join(assets, engine.RedHerringFactsPath); engine.FileExists(facts) {
+		factsPath = facts
+		state.RedFacts = engine.LoadRedHerringFacts(facts)
+	}
+	if status := filepath.Join(assets, engine.ConservationPath); engine.FileExists(status) {
+		state.Conservation = engine.LoadConservation(status)
+	}
+}
+
+// createMapErrorScreen replaces the game when the map cannot be played at all, instead of
+// leaving an empty starter screen.
+func createMapErrorScreen(app fyne.App, win fyne.Window, state *engine.GameState, err error) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	title := widget.NewLabelWithStyle(plain("🚫 This map cannot be played"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	body := newLocalizedText(err.Error()+"\n\nFix "+state.MapPath+" (a map may set Config.StarterLevel to start higher up), or load another map.", fyne.TextAlignCenter)
+
+	load := newButton("Load custom map…", func() { pickCustomMap(app, win, state) })
+	quit := newButton("Quit", app.Quit)
+
+	return NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewCenter(container.NewVBox(title, body, container.NewCenter(container.NewHBox(load, quit))))))
+}
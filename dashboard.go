@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== TERMINAL DASHBOARD =====
+//
+// With --dashboard the GUI mirrors the run to the terminal it was launched from, for
+// when the window is on a second monitor or shared on screen. A status line with the
+// day, host, attempts, evolutions and immune animals is redrawn in place after every
+// event, and the moments worth keeping (run start, evolutions, recoveries, the result)
+// are printed above it. When stdout is not a terminal, every status is printed as a line
+// of its own instead.
+
+// Dashboard renders run events to a terminal.
+type Dashboard struct {
+	mu   sync.Mutex
+	w    io.Writer
+	live bool
+
+	day        int
+	phase      engine.DayPhase
+	host       string
+	attempts   int
+	evolutions int
+	immune     int
+	last       string
+}
+
+func newDashboard(w io.Writer, live bool) *Dashboard {
+	return &Dashboard{w: w, live: live}
+}
+
+// isTerminal reports whether f is a character device rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// attachDashboard mirrors the player's runs to stdout.
+func attachDashboard() {
+	d := newDashboard(os.Stdout, isTerminal(os.Stdout))
+	for _, kind := range []string{engine.EventRunStarted, engine.EventPhase, engine.EventOutcome, engine.EventRecovered, engine.EventRunEnded} {
+		engine.Events.Subscribe(kind, d.handle)
+	}
+	shutdown.OnShutdown("dashboard", d.close)
+}
+
+func (d *Dashboard) handle(ev engine.Event) {
+	if ev.Bot {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.day, d.phase, d.host, d.attempts = ev.Day, ev.Phase, ev.Host, ev.Attempts
+	switch ev.Kind {
+	case engine.EventRunStarted:
+		d.evolutions, d.immune, d.last = 0, 0, ""
+		d.note(fmt.Sprintf("New run: patient zero is %s", ev.Detail))
+	case engine.EventOutcome:
+		d.last = ev.Detail + ": " + outcomeText(ev.Magnitude)
+		if ev.Magnitude == engine.MagnitudeEvolution {
+			d.evolutions++
+			d.note(fmt.Sprintf("Day %d: evolved into %s", ev.Day, ev.Detail))
+		}
+	case engine.EventRecovered:
+		d.immune++
+		d.note(fmt.Sprintf("Day %d: %s recovered and is immune", ev.Day, ev.Detail))
+	case engine.EventRunEnded:
+		result := "lost"
+		if ev.Won {
+			result = "won"
+		}
+		d.note(fmt.Sprintf("Run %s on day %d as %s after %d attempts, score %d", result, ev.Day, ev.Host, ev.Attempts, ev.Score))
+	}
+	d.draw()
+}
+
+// close ends the status line so the shell prompt starts on a fresh one.
+func (d *Dashboard) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.live && d.host != "" {
+		fmt.Fprintln(d.w)
+	}
+}
+
+// note prints a line that stays in the scrollback above the status line.
+func (d *Dashboard) note(line string) {
+	if d.live {
+		fmt.Fprint(d.w, "\r\x1b[K")
+	}
+	fmt.Fprintln(d.w, line)
+}
+
+func (d *Dashboard) draw() {
+	if d.host == "" {
+		return
+	}
+	parts := []string{
+		fmt.Sprintf("Day %d %s", d.day, d.phase),
+		"host " + d.host,
+		fmt.Sprintf("%d attempts", d.attempts),
+		fmt.Sprintf("%d evolutions", d.evolutions),
+	}
+	if d.immune > 0 {
+		parts = append(parts, fmt.Sprintf("%d immune", d.immune))
+	}
+	if d.last != "" {
+		parts = append(parts, "last: "+d.last)
+	}
+	status := strings.Join(parts, " | ")
+	if d.live {
+		fmt.Fprint(d.w, "\r\x1b[K"+status)
+		return
+	}
+	fmt.Fprintln(d.w, status)
+}
+
+// outcomeText names what an attempt of the given magnitude did.
+func outcomeText(m engine.Magnitude) string {
+	switch m {
+	case engine.MagnitudeCloseCall:
+		return "resisted (close call)"
+	case engine.MagnitudeDefenseBroken:
+		return "defense broken"
+	case engine.MagnitudeSameLevel:
+		return "infected"
+	case engine.MagnitudeEvolution:
+		return "evolved"
+	case engine.MagnitudeApex:
+		return "apex reached"
+	default:
+		return "missed"
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== DATA DIRECTORIES =====
+
+const appDirName = "rawr"
+
+// DataDirs are the per-user directories under the OS config directory
+// (~/.config/rawr, %AppData%\rawr, ~/Library/Application Support/rawr). Files bundled
+// next to the binary remain the fallback for anything the user has no copy of.
+type DataDirs struct {
+	// Config holds settings such as theme.json.
+	Config string
+	// Profile holds per-player data.
+	Profile string
+	// Maps holds user maps, checked before the bundled ones.
+	Maps string
+}
+
+var dataDirs DataDirs
+
+// sampleMaps are the bundled maps offered for copying on first launch.
+var sampleMaps = []string{engine.DefaultMapPath}
+
+func defaultDataDirs() (DataDirs, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return DataDirs{}, err
+	}
+	root := filepath.Join(base, appDirName)
+	return DataDirs{
+		Config:  root,
+		Profile: filepath.Join(root, "profile"),
+		Maps:    filepath.Join(root, "maps"),
+	}, nil
+}
+
+// Missing lists the directories that do not exist yet; all of them on first launch.
+func (d DataDirs) Missing() []string {
+	var missing []string
+	for _, dir := range []string{d.Config, d.Profile, d.Maps} {
+		if dir == "" {
+			continue
+		}
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			missing = append(missing, dir)
+		}
+	}
+	return missing
+}
+
+func (d DataDirs) Create() error {
+	for _, dir := range d.Missing() {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns the user's copy of a bundled file from dir if there is one, and the
+// bundled path otherwise.
+func (d DataDirs) Find(dir, bundled string) string {
+	if dir == "" {
+		return bundled
+	}
+	user := filepath.Join(dir, filepath.Base(bundled))
+	if _, err := os.Stat(user); err == nil {
+		return user
+	}
+	return bundled
+}
+
+// CopySampleMaps copies the bundled maps into Maps, leaving existing files alone, and
+// returns how many were copied.
+func (d DataDirs) CopySampleMaps() (int, error) {
+	copied := 0
+	for _, src := range sampleMaps {
+		dst := filepath.Join(d.Maps, filepath.Base(src))
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := copyFile(src, dst); err != nil {
+			return copied, err
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// createWelcomeScreen is shown once, on the launch that created the data directories.
+func createWelcomeScreen(app fyne.App, win fyne.Window, state *engine.GameState, created []string) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	title := widget.NewLabelWithStyle(plain("🦠 Welcome to Yellowstone Outbreak 🦠"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	body := newLocalizedText("Your settings and profile live in:\n"+strings.Join(created, "\n")+
+		"\n\nMaps placed in "+dataDirs.Maps+" are used instead of the bundled ones.", fyne.TextAlignCenter)
+
+	copyMaps := widget.NewCheck("Copy the sample maps there so I can edit them", func(on bool) {
+		inputs.Record(fmt.Sprintf("copymaps:%t", on))
+	})
+	copyMaps.SetChecked(true)
+	inputs.Handle("copymaps:true", func() { copyMaps.SetChecked(true) })
+	inputs.Handle("copymaps:false", func() { copyMaps.SetChecked(false) })
+
+	start := newButton("Continue", inputs.Bind("welcome", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+		if !copyMaps.Checked {
+			return
+		}
+		if _, err := dataDirs.CopySampleMaps(); err != nil {
+			showInformation(state, "Could Not Copy Maps", err.Error(), win)
+		}
+	}))
+
+	return NewClickInterceptor(container.NewMax(
+		loadBackground(),
+		container.NewCenter(container.NewVBox(layout.NewSpacer(), title, body, container.NewCenter(copyMaps), start, layout.NewSpacer())),
+	))
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/fsnotify/fsnotify"
+	"yellowstone_evolution/engine"
+)
+
+// ===== DEV MODE HOT RELOAD =====
+
+// redrawScreen rebuilds whichever screen is showing; screens that can be rebuilt
+// from state alone set it, everything else clears it.
+var redrawScreen func()
+
+// currentRun is the run the window shows. Every screen built from a run sets it with
+// showRun, so a reload follows Play Again, a new seed or map, NG+, a continued game
+// and a change of ecosystem rather than the run the game started with.
+var currentRun *engine.GameState
+
+const reloadDebounce = 250 * time.Millisecond
+
+var (
+	// reloadWatcher watches the current run's files in dev mode; nil otherwise.
+	reloadWatcher *fsnotify.Watcher
+	reloadPending *time.Timer
+)
+
+// showRun makes state the current run and, in dev mode, watches the folders of its map,
+// its red herring facts and its images.
+func showRun(state *engine.GameState) {
+	currentRun = state
+	if reloadWatcher == nil {
+		return
+	}
+	for _, dir := range []string{filepath.Dir(state.MapPath), filepath.Dir(factsPath), engine.ImageDir} {
+		if err := reloadWatcher.Add(dir); err != nil {
+			slog.Warn("reload watch failed", "dir", dir, "err", err)
+		}
+	}
+}
+
+// WatchForReload hot-reloads the current run's map and script, red herring facts and
+// animal images while the game is running, so map authors can iterate without
+// restarting.
+func WatchForReload(state *engine.GameState) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	shutdown.OnShutdown("dev reload watcher", func() { _ = w.Close() })
+	reloadWatcher = w
+	showRun(state)
+
+	go func() {
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				path := filepath.Clean(ev.Name)
+				fyne.Do(func() { fileChanged(path) })
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				slog.Error("reload watch failed", "err", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// fileChanged schedules a reload if path belongs to the current run. Like the rest of
+// the reload, it runs on the UI goroutine, which owns the run.
+func fileChanged(path string) {
+	run := currentRun
+	if run == nil {
+		return
+	}
+	watched := path == filepath.Clean(run.MapPath) || path == filepath.Clean(factsPath)
+	if script := run.MapConfig.Script; script != "" && path == filepath.Clean(engine.ScriptPath(run.MapPath, script)) {
+		watched = true
+	}
+	if !watched && filepath.Dir(path) != filepath.Clean(engine.ImageDir) {
+		return
+	}
+	images.Invalidate(path)
+	if reloadPending != nil {
+		reloadPending.Stop()
+	}
+	reloadPending = time.AfterFunc(reloadDebounce, func() {
+		fyne.Do(func() {
+			reloadGameData(currentRun)
+			if redrawScreen != nil {
+				redrawScreen()
+			}
+		})
+	})
+}
+
+// reloadGameData swaps in fresh data from disk while keeping per-run state; see
+// engine.ReloadMap.
+func reloadGameData(state *engine.GameState) {
+	if err := engine.ReloadMap(state); err != nil {
+		slog.Warn("reload skipped, keeping the old data", "err", err)
+		return
+	}
+	state.RedFacts = engine.LoadRedHerringFacts(factsPath)
+	audio.PreloadAnimals(state.Animals)
+	slog.Info("reloaded", "map", state.MapPath)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== ECOSYSTEMS =====
+//
+// Every map file in the bundled data/ directory and in the user's maps directory is an
+// ecosystem the player can pick at startup. A map names itself with Config.Name and may
+// bring its own Config.Background and Config.Assets; the user's copy of a map replaces
+// the bundled file of the same name.
+
+const (
+	bundledMapsDir    = "data"
+	defaultBackground = "yellowstone.png"
+)
+
+// backgroundPath is the image behind every screen; an ecosystem can set its own.
+var backgroundPath = defaultBackground
+
+// Ecosystem is one playable map as the selection screen lists it.
+type Ecosystem struct {
+	Name        string
+	Path        string
+	Animals     int
+	MaxLevel    int
+	RedHerrings int
+	Background  string
+	Assets      string
+}
+
+// scanEcosystems lists the maps in the user's maps directory and in data/, by name.
+// Files without any animals are not maps and are left out.
+func scanEcosystems() []Ecosystem {
+	byFile := map[string]string{}
+	for _, dir := range []string{bundledMapsDir, dataDirs.Maps} {
+		if dir == "" {
+			continue
+		}
+		paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, p := range paths {
+			byFile[filepath.Base(p)] = p
+		}
+	}
+
+	var out []Ecosystem
+	for _, path := range byFile {
+		r := engine.LoadRoster(path)
+		if len(r.Animals) == 0 {
+			continue
+		}
+		e := ecosystemOf(path, engine.LoadMapConfig(path))
+		e.Animals, e.MaxLevel = len(r.Animals), r.MaxLevel
+		for _, a := range r.Animals {
+			if a.RedHerring {
+				e.RedHerrings++
+			}
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// ecosystemOf names the map at path and resolves its background and assets, which
+// fall back to the bundled ones.
+func ecosystemOf(path string, cfg engine.MapConfig) Ecosystem {
+	e := Ecosystem{Name: cfg.Name, Path: path, Background: defaultBackground}
+	if e.Name == "" {
+		stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		stem = strings.TrimSuffix(stem, "_animals")
+		e.Name = titleCase(strings.NewReplacer("_", " ", "-", " ").Replace(stem))
+	}
+	if bg := engine.ScriptPath(path, cfg.Background); bg != "" && engine.FileExists(bg) {
+		e.Background = bg
+	}
+	if assets := engine.ScriptPath(path, cfg.Assets); assets != "" {
+		if info, err := os.Stat(assets); err == nil && info.IsDir() {
+			e.Assets = assets
+		}
+	}
+	return e
+}
+
+// useEcosystem dresses the game in state's map: its background and its own portraits
+// and red herring facts, or the bundled ones.
+func useEcosystem(state *engine.GameState) {
+	e := ecosystemOf(state.MapPath, state.MapConfig)
+	backgroundPath = e.Background
+	useAssets(state, e.Assets)
+}
+
+func createEcosystemScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	title := widget.NewLabelWithStyle(plain("🌍 Choose an Ecosystem"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	var cards []fyne.CanvasObject
+	for _, e := range scanEcosystems() {
+		var preview fyne.CanvasObject = layout.NewSpacer()
+		if !liteMode {
+			img := canvas.NewImageFromFile(e.Background)
+			img.FillMode = canvas.ImageFillContain
+			img.SetMinSize(fyne.NewSize(240, 135))
+			preview = img
+		}
+		name := widget.NewLabelWithStyle(plain(e.Name), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+		detail := newLabel(fmt.Sprintf("%d animals · %d levels · %d red herrings", e.Animals, e.MaxLevel, e.RedHerrings))
+		detail.Alignment = fyne.TextAlignCenter
+
+		choose := newButton("Play "+e.Name, inputs.Bind("ecosystem:"+e.Name, func() {
+			if e.Path == state.MapPath {
+				win.SetContent(createIntroScreen(app, win, state))
+				return
+			}
+			if err := validateMap(e.Path); err != nil {
+				showInformation(state, "Cannot Play "+e.Name, err.Error(), win)
+				return
+			}
+			swapMap(app, win, state, e.Path, e.Assets)
+		}))
+		if e.Path == state.MapPath {
+			choose.SetText(plain("✓ " + e.Name))
+			choose.Importance = widget.HighImportance
+		}
+		cards = append(cards, container.NewVBox(container.NewCenter(preview), name, detail, container.NewCenter(choose)))
+	}
+
+	return NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewBorder(title, nil, nil, nil, container.NewScroll(container.NewGridWithColumns(3, cards...)))))
+}
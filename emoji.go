@@ -0,0 +1,115 @@
+package main
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== EMOJI-FREE TEXT AND MAP FONTS =====
+//
+// Emoji render differently, or not at all, depending on the platform's fonts. With the
+// emoji-free option on, UI text drops its emoji and buttons show the matching theme
+// icon instead. Text built through newButton, newLabel and plain follows the option;
+// it applies to screens built after it changes.
+
+const prefNoEmoji = "noEmoji"
+
+// noEmoji is the emoji-free option, set at startup and from the intro screen.
+var noEmoji bool
+
+// emojiIcons are the theme icons that stand in for a button's leading emoji.
+var emojiIcons = map[string]fyne.Resource{
+	"⬅": theme.NavigateBackIcon(),
+	"⏭": theme.MediaSkipNextIcon(),
+	"⏸": theme.MediaPauseIcon(),
+	"📓": theme.DocumentIcon(),
+	"🏛": theme.HistoryIcon(),
+	"📊": theme.ListIcon(),
+	"🎲": theme.ViewRefreshIcon(),
+	"🧪": theme.ContentCopyIcon(),
+	"↶": theme.ContentUndoIcon(),
+	"🏷": theme.DocumentSaveIcon(),
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, transport, symbols
+		r >= 0x2600 && r <= 0x27BF, // miscellaneous symbols and dingbats
+		r >= 0x2300 && r <= 0x23FF, // technical symbols such as ⏳ and ⏸
+		r >= 0x2B00 && r <= 0x2BFF, // arrows such as ⬅
+		r == 0x21B6,                // ↶
+		r == 0xFE0F, r == 0x200D:   // variation selector and zero-width joiner
+		return true
+	}
+	return false
+}
+
+// plain drops emoji from s when the emoji-free option is on.
+func plain(s string) string {
+	if !noEmoji {
+		return s
+	}
+	s = strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, s)
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.Join(strings.Fields(l), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newButton is widget.NewButton following the emoji-free option: a leading emoji with a
+// matching theme icon becomes that icon.
+func newButton(label string, tapped func()) *widget.Button {
+	if noEmoji {
+		first, rest, _ := strings.Cut(label, " ")
+		if icon, ok := emojiIcons[first]; ok {
+			return widget.NewButtonWithIcon(plain(rest), icon, tapped)
+		}
+	}
+	return widget.NewButton(plain(label), tapped)
+}
+
+// newLabel is widget.NewLabel following the emoji-free option.
+func newLabel(text string) *widget.Label {
+	return widget.NewLabel(plain(text))
+}
+
+// mapFont loads the map's Config.Font, relative to the map file, or returns nil.
+func mapFont(state *engine.GameState) fyne.Resource {
+	path := state.MapConfig.Font
+	if path == "" {
+		return nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(state.MapPath), path)
+	}
+	font, err := fyne.LoadResourceFromPath(path)
+	if err != nil {
+		slog.Warn("map font unreadable", "err", err)
+		return nil
+	}
+	return font
+}
+
+// newFlavorText is wrapped text for the map's own writing (facts and reasons), drawn in
+// the map's font if it sets one.
+func newFlavorText(state *engine.GameState, text string) fyne.CanvasObject {
+	body := newLocalizedText(text, fyne.TextAlignLeading)
+	font := mapFont(state)
+	if font == nil {
+		return body
+	}
+	return container.NewThemeOverride(body, &localeTheme{Theme: fyne.CurrentApp().Settings().Theme(), font: font})
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ===== APEX ENCOUNTER =====
+
+// ResistancePhase is one layer of an animal's defenses. Each phase must be broken by a
+// successful roll before the animal can be infected, and the roll is only allowed once
+// enough adjacent carriers are infected and the virus has the required modes.
+type ResistancePhase struct {
+	Name     string   `json:"Name"`
+	Carriers int      `json:"Carriers"`
+	Modes    []string `json:"Modes"`
+}
+
+// IsAdjacent treats the contact graph as undirected.
+func IsAdjacent(a, b *Animal) bool {
+	_, ok := EdgeWeight(a, b)
+	return ok
+}
+
+// carrierCount is the number of infected animals adjacent to a in the contact graph.
+func carrierCount(state *GameState, a *Animal) int {
+	n := 0
+	for _, other := range state.Animals {
+		if other != a && other.Infected && IsAdjacent(a, other) {
+			n++
+		}
+	}
+	return n
+}
+
+// CurrentResistancePhase returns the next unbroken phase and its index, or nil once
+// all of the animal's defenses are down.
+func CurrentResistancePhase(state *GameState, a *Animal) (*ResistancePhase, int) {
+	i := state.BrokenPhases[a.Name]
+	if i >= len(a.ResistancePhases) {
+		return nil, i
+	}
+	return &a.ResistancePhases[i], i
+}
+
+// missingRequirements describes what still blocks an attempt on the phase, or "" if nothing does.
+func missingRequirements(state *GameState, a *Animal, phase *ResistancePhase) string {
+	var missing []string
+	if have := carrierCount(state, a); have < phase.Carriers {
+		missing = append(missing, fmt.Sprintf("%d/%d carriers", have, phase.Carriers))
+	}
+	for _, m := range phase.Modes {
+		if !state.Virus.HasMode(m) {
+			missing = append(missing, m+" mode")
+		}
+	}
+	return strings.Join(missing, ", ")
+}
+
+func (v *Virus) HasMode(mode string) bool {
+	for _, m := range v.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// ===== APEX ENDINGS =====
+//
+// A map may have several apexes, the animals of its top level, and under apex victory
+// taking any one of them wins. Each apex can bring its own Ending: the title and text
+// the win screen tells, and a score bonus that makes the harder boss worth choosing.
+// An apex without one ends the run the classic way, with no bonus.
+
+// ApexEnding is what winning on one apex means.
+type ApexEnding struct {
+	Title      string `json:"Title"`
+	Text       string `json:"Text"`
+	ScoreBonus int    `json:"ScoreBonus"`
+}
+
+// Apexes lists the animals that win an apex run, by name.
+func Apexes(state *GameState) []*Animal {
+	var out []*Animal
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; a.Level == state.MaxLevel && !a.RedHerring && !a.Juvenile {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// EndingOf returns a's ending, or the classic one for an apex that sets none.
+func EndingOf(a *Animal) ApexEnding {
+	if a.Ending != nil {
+		return *a.Ending
+	}
+	return ApexEnding{}
+}
+
+// EndingBonus is the score bonus for winning on a, or 0 under outbreak victory or when
+// a is not an apex.
+func EndingBonus(state *GameState, a *Animal) int {
+	if a == nil || state.Rules.OutbreakPercent > 0 || a.Level != state.MaxLevel {
+		return 0
+	}
+	return EndingOf(a).ScoreBonus
+}
+
+// EndingLine describes winning on a for the menus, e.g. "Coywolf Hybrid: The Hybrid
+// Dawn, +300".
+func EndingLine(a *Animal) string {
+	e := EndingOf(a)
+	line := a.Name
+	if e.Title != "" {
+		line += ": " + e.Title
+	}
+	if e.ScoreBonus != 0 {
+		line += fmt.Sprintf(", %+d", e.ScoreBonus)
+	}
+	return line
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ===== MUTATION BURST =====
+//
+// Once per run the player may go all in: the next attempt's chance is multiplied by
+// BurstChanceFactor, but if the target resists, the virus is weakened to
+// BurstStrengthFactor for BurstPenaltyDays days. The burst stays armed through red
+// herrings, since they are not a roll.
+//
+// The weakening is a TimedEffect, the general form of a temporary change to the virus
+// that runs out on a given day.
+
+const (
+	BurstChanceFactor   = 2.0
+	BurstStrengthFactor = 0.5
+	BurstPenaltyDays    = 3
+)
+
+var ErrBurstUsed = errors.New("the mutation burst has already been used this run")
+
+// TimedEffect scales the virus strength until the morning of day Until; a Blind effect
+// hides the infection chances instead (see curses.go).
+type TimedEffect struct {
+	Name           string
+	StrengthFactor float64
+	Blind          bool `json:",omitempty"`
+	Until          int
+}
+
+// VirusStrength is the virus strength with every active effect applied.
+func VirusStrength(state *GameState) float64 {
+	s := state.Virus.Strength
+	for _, e := range state.Effects {
+		s *= e.StrengthFactor
+	}
+	return s
+}
+
+// expireEffects drops the effects that have run out; it runs at the start of each day.
+func expireEffects(state *GameState) {
+	kept := state.Effects[:0]
+	for _, e := range state.Effects {
+		if e.Until > state.CurrentDay {
+			kept = append(kept, e)
+		}
+	}
+	state.Effects = kept
+}
+
+// EffectLines describe the active effects for the HUD.
+func EffectLines(state *GameState) []string {
+	var out []string
+	for _, e := range state.Effects {
+		if e.Blind {
+			out = append(out, fmt.Sprintf("%s: chances hidden until day %d", e.Name, e.Until))
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s: strength ×%.2f until day %d", e.Name, e.StrengthFactor, e.Until))
+	}
+	return out
+}
+
+// ToggleBurst arms the mutation burst for the next attempt, or disarms it.
+func ToggleBurst(state *GameState) error {
+	if state.BurstUsed {
+		return ErrBurstUsed
+	}
+	state.BurstArmed = !state.BurstArmed
+	if state.BurstArmed {
+		recordStep(state, "burst", "armed")
+	} else {
+		recordStep(state, "burst", "disarmed")
+	}
+	return nil
+}
+
+// burstFactor is what the armed burst multiplies the next chance by.
+func burstFactor(state *GameState) float64 {
+	if state.BurstArmed {
+		return BurstChanceFactor
+	}
+	return 1
+}
+
+// spendBurst uses up the armed burst on the roll being made and reports whether there
+// was one.
+func spendBurst(state *GameState) bool {
+	if !state.BurstArmed {
+		return false
+	}
+	state.BurstArmed, state.BurstUsed = false, true
+	return true
+}
+
+// burstBackfire weakens the virus after a burst roll failed, and describes it.
+func burstBackfire(state *GameState) string {
+	until := state.CurrentDay + BurstPenaltyDays
+	state.Effects = append(state.Effects, TimedEffect{Name: "🎰 Burst backlash", StrengthFactor: BurstStrengthFactor, Until: until})
+	return fmt.Sprintf("🎰 The mutation burst backfired: the virus is at ×%.2f strength until day %d.", BurstStrengthFactor, until)
+}
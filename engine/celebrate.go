@@ -0,0 +1,42 @@
+package engine
+
+// ===== CELEBRATION =====
+
+// MedalConfig sets the winning scores for each medal, per map under "Config". Zero
+// fields use defaultMedals.
+type MedalConfig struct {
+	Bronze int `json:"Bronze"`
+	Silver int `json:"Silver"`
+	Gold   int `json:"Gold"`
+}
+
+var defaultMedals = MedalConfig{Bronze: 1000, Silver: 1300, Gold: 1600}
+
+// MedalsOf is state's map's medal thresholds with defaults filled in.
+func MedalsOf(state *GameState) MedalConfig {
+	m := state.MapConfig.Medals
+	if m.Bronze == 0 {
+		m.Bronze = defaultMedals.Bronze
+	}
+	if m.Silver == 0 {
+		m.Silver = defaultMedals.Silver
+	}
+	if m.Gold == 0 {
+		m.Gold = defaultMedals.Gold
+	}
+	return m
+}
+
+// MedalFor names the medal a winning score earns on state's map, or "" for none.
+func MedalFor(state *GameState, score int) string {
+	m := MedalsOf(state)
+	switch {
+	case score >= m.Gold:
+		return "🥇 Gold"
+	case score >= m.Silver:
+		return "🥈 Silver"
+	case score >= m.Bronze:
+		return "🥉 Bronze"
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// ===== GAME CLOCK =====
+
+// GameClock measures in-game time: wall time since Start minus any time spent paused.
+// Pauses nest, so a dialog opened while the game is paused does not resume it on close.
+type GameClock struct {
+	mu       sync.Mutex
+	start    time.Time
+	pausedAt time.Time
+	paused   time.Duration
+	depth    int
+}
+
+func (c *GameClock) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start = time.Now()
+	c.paused = 0
+	c.depth = 0
+}
+
+// StartFrom starts the clock as if elapsed game time had already passed, for a resumed run.
+func (c *GameClock) StartFrom(elapsed time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.start = time.Now().Add(-elapsed)
+	c.paused = 0
+	c.depth = 0
+}
+
+// SetElapsed winds the clock to elapsed game time, e.g. when practice mode returns to
+// a branch; a paused clock stays paused.
+func (c *GameClock) SetElapsed(elapsed time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.start = now.Add(-elapsed)
+	c.paused = 0
+	if c.depth > 0 {
+		c.pausedAt = now
+	}
+}
+
+func (c *GameClock) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.depth == 0 {
+		c.pausedAt = time.Now()
+	}
+	c.depth++
+}
+
+func (c *GameClock) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.depth == 0 {
+		return
+	}
+	c.depth--
+	if c.depth == 0 {
+		c.paused += time.Since(c.pausedAt)
+	}
+}
+
+func (c *GameClock) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.depth > 0
+}
+
+// Elapsed is the game time used for scoring.
+func (c *GameClock) Elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.start.IsZero() {
+		return 0
+	}
+	paused := c.paused
+	if c.depth > 0 {
+		paused += time.Since(c.pausedAt)
+	}
+	return time.Since(c.start) - paused
+}
+
+// WallElapsed is the raw real-world time since Start, pauses included.
+func (c *GameClock) WallElapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.start.IsZero() {
+		return 0
+	}
+	return time.Since(c.start)
+}
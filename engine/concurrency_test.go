@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// botResult is what a bot run in the tests ended as.
+type botResult struct {
+	Won, Lost bool
+	Host      string
+	Attempts  int
+	Days      int
+	Score     int
+}
+
+// playBot plays one run greedily: the likeliest starter, then the likeliest open target,
+// preferring a step up, and a wait when there is none.
+func playBot(mapPath string, rules RulesConfig, seed int64) botResult {
+	state := NewGameState(mapPath)
+	state.Rules = rules
+	SeedRun(state, seed)
+	state.BotPlayed = true
+
+	var starter *Animal
+	for _, a := range StarterCandidates(state) {
+		if !a.RedHerring && (starter == nil || a.InfectionRate > starter.InfectionRate) {
+			starter = a
+		}
+	}
+	var res botResult
+	if starter == nil || ChooseStarter(state, starter) != nil {
+		return res
+	}
+
+	herrings := map[string]bool{}
+	for step := 0; step < 2000 && !res.Won && !res.Lost; step++ {
+		host := state.Animals[state.PlayerName]
+		var target *Animal
+		best := -1.0
+		for _, a := range CandidateTargets(state) {
+			if herrings[a.Name] || BlockedReason(state, a) != "" {
+				continue
+			}
+			score := InfectionChance(state, a)
+			if a.Level > host.Level {
+				score++
+			}
+			if score > best {
+				target, best = a, score
+			}
+		}
+		if target == nil {
+			res.Won, res.Lost = WaitOut(state)
+			continue
+		}
+		out := AttemptInfection(state, target)
+		if out.Kind == OutcomeRedHerring {
+			herrings[target.Name] = true
+		}
+		res.Won, res.Lost = out.Won, out.Lost
+	}
+
+	res.Host = state.PlayerName
+	res.Attempts = state.Stats.Attempts
+	res.Days = state.CurrentDay
+	res.Score = CalculateScore(state)
+	return res
+}
+
+// TestConcurrentRunsWithObservers plays bot runs on several goroutines at once, each
+// with its own state, while observers on the shared event bus count what they see.
+// Run it with -race: the runs share the bus and the map data on disk, and must not
+// share anything else.
+func TestConcurrentRunsWithObservers(t *testing.T) {
+	var seen atomic.Int64
+	for _, kind := range []string{EventOutcome, EventPhase, EventRunStarted, EventRunEnded, EventRecovered} {
+		Events.Subscribe(kind, func(Event) { seen.Add(1) })
+	}
+
+	mapPath := filepath.Join("..", DefaultMapPath)
+	rules := RulesConfig{PassiveSpread: true, Mutations: true, Immunity: true, Curses: true}
+	const n = 8
+	runs := make([]botResult, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Pairs share a seed, so each run can be checked against its twin.
+			runs[i] = playBot(mapPath, rules, int64(i/2+1))
+		}()
+	}
+	wg.Wait()
+
+	if seen.Load() == 0 {
+		t.Fatal("observers saw no events")
+	}
+	for i := 0; i < n; i += 2 {
+		if runs[i] != runs[i+1] {
+			t.Errorf("seed %d played differently side by side:\n%+v\n%+v", i/2+1, runs[i], runs[i+1])
+		}
+	}
+}
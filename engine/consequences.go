@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ===== FAILED ATTEMPT CONSEQUENCES =====
+
+const (
+	FailFleeChance = 0.10
+	FailWaryChance = 0.25
+	WaryDays       = 3
+	FleeDays       = 1
+	CooldownDays   = 1
+)
+
+// WaryStatus makes an animal untargetable until the given day.
+type WaryStatus struct {
+	Until int
+	Fled  bool
+}
+
+func isWary(state *GameState, a *Animal) (WaryStatus, bool) {
+	w, ok := state.Wary[a.Name]
+	if !ok || state.CurrentDay >= w.Until {
+		return WaryStatus{}, false
+	}
+	return w, true
+}
+
+// OnCooldown returns how many days remain before a can be retried under the retry
+// cooldown rule.
+func OnCooldown(state *GameState, a *Animal) (int, bool) {
+	until, ok := state.Cooldowns[a.Name]
+	if !ok || state.CurrentDay >= until {
+		return 0, false
+	}
+	return until - state.CurrentDay, true
+}
+
+// applyFailureConsequence rolls whether a target that resisted flees to another region
+// or turns wary, and returns a sentence describing what happened ("" if nothing did).
+func applyFailureConsequence(state *GameState, a *Animal) string {
+	r := state.RNG.Float64()
+	switch {
+	case r < FailFleeChance:
+		from := a.Location
+		a.Location = pickOtherLocation(state, from)
+		state.Wary[a.Name] = WaryStatus{Until: state.CurrentDay + FleeDays, Fled: true}
+		return fmt.Sprintf("🏃 %s fled from %s to %s.", a.Name, from, a.Location)
+	case r < FailFleeChance+FailWaryChance:
+		state.Wary[a.Name] = WaryStatus{Until: state.CurrentDay + WaryDays}
+		return fmt.Sprintf("😠 %s is wary and will avoid you for %d days.", a.Name, WaryDays)
+	}
+	return ""
+}
+
+func pickOtherLocation(state *GameState, current string) string {
+	seen := map[string]bool{}
+	var locations []string
+	for _, a := range state.Animals {
+		if a.Location != "" && a.Location != current && !seen[a.Location] {
+			seen[a.Location] = true
+			locations = append(locations, a.Location)
+		}
+	}
+	if len(locations) == 0 {
+		return current
+	}
+	sort.Strings(locations)
+	return locations[state.RNG.Intn(len(locations))]
+}
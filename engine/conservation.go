@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+)
+
+// ===== CONSERVATION STATUS =====
+//
+// After a run the player can look up the real species they met. The statuses come from a
+// bundled dataset of IUCN Red List categories, so nothing is fetched over the network;
+// a custom map's asset folder may ship its own conservation_status.json.
+
+const ConservationPath = "conservation_status.json"
+
+// ConservationInfo is one species' entry in the dataset.
+type ConservationInfo struct {
+	Scientific string `json:"Scientific"`
+	// Status is an IUCN Red List category code, e.g. "LC" or "NT".
+	Status string `json:"Status"`
+	Fact   string `json:"Fact"`
+}
+
+// IUCNCategories names the Red List category codes, from least to most at risk.
+var IUCNCategories = []struct{ Code, Name string }{
+	{"NE", "Not Evaluated"},
+	{"DD", "Data Deficient"},
+	{"LC", "Least Concern"},
+	{"NT", "Near Threatened"},
+	{"VU", "Vulnerable"},
+	{"EN", "Endangered"},
+	{"CR", "Critically Endangered"},
+	{"EW", "Extinct in the Wild"},
+	{"EX", "Extinct"},
+}
+
+func (c ConservationInfo) StatusName() string {
+	for _, cat := range IUCNCategories {
+		if cat.Code == c.Status {
+			return cat.Name
+		}
+	}
+	return c.Status
+}
+
+func LoadConservation(path string) map[string]ConservationInfo {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return map[string]ConservationInfo{}
+	}
+	var out map[string]ConservationInfo
+	if err := json.Unmarshal(data, &out); err != nil {
+		slog.Warn("conservation data unreadable", "err", err)
+		return map[string]ConservationInfo{}
+	}
+	return out
+}
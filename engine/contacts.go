@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ===== CONTACT GRAPH =====
+
+// Contact is a weighted edge of the contact graph. Weight is a chance multiplier: 1.0 is
+// an ordinary relationship, below 1 a weak one, above 1 a close one. Kind, if set, is
+// what the other animal is to this one; see relationships below.
+type Contact struct {
+	Name   string  `json:"Name"`
+	Weight float64 `json:"Weight"`
+	Kind   string  `json:"Kind,omitempty"`
+}
+
+// ContactList accepts all three map formats, so older maps keep loading:
+//
+//	"Contacts": ["Gray Wolf", "Coyote"]
+//	"Contacts": [{"Name": "Gray Wolf", "Weight": 1.3}, "Coyote"]
+//	"Contacts": [{"Name": "Red Fox", "Kind": "prey"}, "Coyote"]
+//
+// Plain names and entries without a weight become weight 1.0 and an ordinary contact.
+type ContactList []Contact
+
+func (c *ContactList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	out := make(ContactList, 0, len(raw))
+	for _, item := range raw {
+		var name string
+		if json.Unmarshal(item, &name) == nil {
+			out = append(out, Contact{Name: name, Weight: 1})
+			continue
+		}
+		var edge struct {
+			Name   string   `json:"Name"`
+			Weight *float64 `json:"Weight"`
+			Kind   string   `json:"Kind"`
+		}
+		if err := json.Unmarshal(item, &edge); err != nil {
+			return fmt.Errorf("contact %s: %w", item, err)
+		}
+		if !slices.Contains(relationKinds, edge.Kind) {
+			return fmt.Errorf("contact %s: unknown kind %q", edge.Name, edge.Kind)
+		}
+		w := 1.0
+		if edge.Weight != nil {
+			w = *edge.Weight
+		}
+		out = append(out, Contact{Name: edge.Name, Weight: w, Kind: edge.Kind})
+	}
+	*c = out
+	return nil
+}
+
+func (c ContactList) Names() []string {
+	names := make([]string, len(c))
+	for i, e := range c {
+		names[i] = e.Name
+	}
+	return names
+}
+
+func (c ContactList) Weight(name string) (float64, bool) {
+	for _, e := range c {
+		if e.Name == name {
+			return e.Weight, true
+		}
+	}
+	return 0, false
+}
+
+func (c ContactList) Kind(name string) (string, bool) {
+	for _, e := range c {
+		if e.Name == name {
+			return e.Kind, true
+		}
+	}
+	return "", false
+}
+
+// EdgeWeight is the weight between a and b in the undirected graph; if both list each
+// other, the stronger edge wins. Rivals share no edge: the virus never crosses one.
+func EdgeWeight(a, b *Animal) (float64, bool) {
+	if Relation(a, b) == RelationRival {
+		return 0, false
+	}
+	wa, okA := a.Contacts.Weight(b.Name)
+	wb, okB := b.Contacts.Weight(a.Name)
+	switch {
+	case okA && okB:
+		return math.Max(wa, wb), true
+	case okA:
+		return wa, true
+	default:
+		return wb, okB
+	}
+}
+
+// ContactFactor scales the chance on a by its strongest relationship with an infected
+// animal when contact weights are on. Animals with no infected contact are unaffected.
+func ContactFactor(state *GameState, a *Animal) float64 {
+	if !state.Rules.ContactWeighted {
+		return 1
+	}
+	best, found := 0.0, false
+	for _, name := range SortedAnimalNames(state) {
+		other := state.Animals[name]
+		if other == a || !other.Infected {
+			continue
+		}
+		if w, ok := EdgeWeight(a, other); ok && (!found || w > best) {
+			best, found = w, true
+		}
+	}
+	if !found {
+		return 1
+	}
+	return best
+}
+
+// ===== RELATIONSHIPS =====
+//
+// A contact may say what kind of relationship it is. Each kind changes how the virus
+// moves along the edge, and either side may list it:
+//
+//	predator  a predator of the host can be attempted at any level above it, so the
+//	          virus can climb the food chain in one leap
+//	prey      the same edge seen from the predator's side
+//	symbiote  a target with an infected symbiote is at ×SymbioteBonus
+//	rival     the virus never crosses the edge: a rival of the host cannot be
+//	          attempted, and rivals neither spread to nor count as carriers for each other
+//
+// An ordinary contact has no kind.
+
+const (
+	RelationPredator = "predator"
+	RelationPrey     = "prey"
+	RelationSymbiote = "symbiote"
+	RelationRival    = "rival"
+
+	SymbioteBonus = 1.25
+)
+
+var relationKinds = []string{"", RelationPredator, RelationPrey, RelationSymbiote, RelationRival}
+
+// Relation is what b is to a, from whichever of them lists the other, or "" for an
+// ordinary contact or none. A prey edge on b's side makes b a's predator, and back.
+func Relation(a, b *Animal) string {
+	for _, c := range a.Contacts {
+		if c.Name == b.Name && c.Kind != "" {
+			return c.Kind
+		}
+	}
+	for _, c := range b.Contacts {
+		if c.Name == a.Name {
+			switch c.Kind {
+			case RelationPredator:
+				return RelationPrey
+			case RelationPrey:
+				return RelationPredator
+			default:
+				return c.Kind
+			}
+		}
+	}
+	return ""
+}
+
+// PreysOn reports whether a is a predator of host, so the virus may leap up to it.
+func PreysOn(a, host *Animal) bool {
+	return Relation(host, a) == RelationPredator
+}
+
+// SymbioteFactor is SymbioteBonus when one of a's symbiotes is infected, else 1.
+func SymbioteFactor(state *GameState, a *Animal) float64 {
+	for _, name := range SortedAnimalNames(state) {
+		if other := state.Animals[name]; other != a && other.Infected && Relation(a, other) == RelationSymbiote {
+			return SymbioteBonus
+		}
+	}
+	return 1
+}
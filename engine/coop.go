@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// ===== PASS AND PLAY =====
+//
+// With more than one player, the players share one virus and take turns on one device:
+// every attempt or wait is a turn. What an attempt reveals about its target (resisted,
+// a red herring, a broken defense) goes into the notes of the player who made it, and
+// the board and card info only ever show the notes of the player whose turn it is.
+// Between turns a privacy screen covers the board until the next player takes the device.
+
+// PlayerOptions are the player counts offered at run start; 1 is a solo run.
+var PlayerOptions = []int{1, 2, 3, 4}
+
+// PassAndPlay is the turn order and each player's knowledge.
+type PassAndPlay struct {
+	Turn int `json:"turn"`
+	// Notes holds, per player, the last interaction that player saw with each animal.
+	Notes []map[string]string `json:"notes"`
+	// Passing is set from the end of a turn until the next player reveals their board.
+	Passing bool `json:"passing,omitempty"`
+}
+
+func PlayersDescription(n int) string {
+	if n <= 1 {
+		return "Solo"
+	}
+	return fmt.Sprintf("%d players, pass and play", n)
+}
+
+func PlayerLabel(i int) string {
+	return fmt.Sprintf("Player %d", i+1)
+}
+
+// PassAndPlayOf returns the run's turn state, starting it on first use, or nil in a solo
+// run.
+func PassAndPlayOf(state *GameState) *PassAndPlay {
+	n := state.Rules.Players
+	if n <= 1 {
+		return nil
+	}
+	if state.Coop == nil || len(state.Coop.Notes) != n {
+		state.Coop = &PassAndPlay{Notes: make([]map[string]string, n)}
+		for i := range state.Coop.Notes {
+			state.Coop.Notes[i] = map[string]string{}
+		}
+	}
+	return state.Coop
+}
+
+// KnownInteraction is the last interaction with name that the player on turn knows of.
+func KnownInteraction(state *GameState, name string) (string, bool) {
+	if pp := PassAndPlayOf(state); pp != nil {
+		note, ok := pp.Notes[pp.Turn][name]
+		return note, ok
+	}
+	note, ok := state.LastInteraction[name]
+	return note, ok
+}
+
+// EndTurn files what the turn revealed about target ("" after a wait) in the current
+// player's notes and hands the device on.
+func EndTurn(state *GameState, target string) {
+	pp := PassAndPlayOf(state)
+	if pp == nil {
+		return
+	}
+	if note, ok := state.LastInteraction[target]; ok && target != "" {
+		pp.Notes[pp.Turn][target] = note
+	}
+	pp.Turn = (pp.Turn + 1) % len(pp.Notes)
+	pp.Passing = true
+}
+
+// Passing reports whether the privacy screen should cover the board.
+func Passing(state *GameState) bool {
+	pp := PassAndPlayOf(state)
+	return pp != nil && pp.Passing
+}
+
+// TurnLabel names the player on turn, or "" in a solo run.
+func TurnLabel(state *GameState) string {
+	if pp := PassAndPlayOf(state); pp != nil {
+		return "🎮 " + PlayerLabel(pp.Turn)
+	}
+	return ""
+}
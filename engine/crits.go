@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// ===== CRITICAL OUTCOMES =====
+//
+// Now and then an attempt goes much better or much worse than planned. When an attempt
+// infects its target, a critical success also infects one of the target's uninfected
+// contacts. When a target resists, a critical failure either alerts the rangers, which
+// puts every calm animal on alert, or hurts the host, which weakens the virus for the
+// rest of the run. Maps set the chances under "Config" → "Crits".
+
+const (
+	defaultCritSuccess = 0.04
+	defaultCritFailure = 0.04
+	CritHostDamage     = 0.9
+)
+
+// CritConfig holds a map's crit chances: Success is the chance that an infection is
+// critical, Failure the chance that a resisted attempt is. Zero uses the default and a
+// negative value turns that crit off.
+type CritConfig struct {
+	Success float64 `json:"Success"`
+	Failure float64 `json:"Failure"`
+}
+
+func critChance(v, def float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v == 0:
+		return def
+	}
+	return v
+}
+
+// CritChances are state's map's crit chances with defaults filled in.
+func CritChances(state *GameState) (success, failure float64) {
+	c := state.MapConfig.Crits
+	return critChance(c.Success, defaultCritSuccess), critChance(c.Failure, defaultCritFailure)
+}
+
+// CritOdds is the overall chance that the next attempt on a ends in a critical success
+// or failure, for the chance breakdown. Breaking a defense is never critical.
+func CritOdds(state *GameState, a *Animal) (success, failure float64) {
+	s, f := CritChances(state)
+	chance := InfectionChance(state, a)
+	if MercyApplies(state) {
+		chance = 1
+	}
+	if phase, _ := CurrentResistancePhase(state, a); phase != nil {
+		s = 0
+	}
+	return chance * s, (1 - chance) * f
+}
+
+// rollCritSuccess runs after t is infected by the host. On a critical success one of
+// t's uninfected contacts is infected too, without becoming the host; it returns a
+// sentence describing that, or "".
+func rollCritSuccess(state *GameState, t *Animal) string {
+	s, _ := CritChances(state)
+	if s <= 0 || state.RNG.Float64() >= s {
+		return ""
+	}
+	var contacts []*Animal
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; a != t && !a.Infected && !a.RedHerring && !IsImmune(state, a) && IsAdjacent(a, t) {
+			contacts = append(contacts, a)
+		}
+	}
+	if len(contacts) == 0 {
+		return ""
+	}
+	c := contacts[state.RNG.Intn(len(contacts))]
+	c.Infected = true
+	delete(state.Moods, c.Name)
+	state.LastInteraction[c.Name] = fmt.Sprintf("Day %d %s: infected by %s", state.CurrentDay, state.Phase, t.Name)
+	state.Transmissions = append(state.Transmissions, Transmission{From: t.Name, To: c.Name, Day: state.CurrentDay})
+	return fmt.Sprintf("💥 Critical success! %s also infected %s.", t.Name, c.Name)
+}
+
+// rollCritFailure runs after t resisted. On a critical failure it alerts the rangers or
+// hurts the host, and returns a sentence describing which, or "".
+func rollCritFailure(state *GameState, t *Animal) string {
+	_, f := CritChances(state)
+	if f <= 0 || state.RNG.Float64() >= f {
+		return ""
+	}
+	if state.RNG.Intn(2) == 0 {
+		for _, name := range SortedAnimalNames(state) {
+			if a := state.Animals[name]; !a.Infected && !a.RedHerring && MoodOf(state, a) == MoodCalm {
+				alarm(state, a)
+			}
+		}
+		return "🚨 Critical failure! " + t.Name + " alerted the rangers; every calm animal is now on alert."
+	}
+	state.Virus.Strength *= CritHostDamage
+	return fmt.Sprintf("🩸 Critical failure! %s hurt %s; the virus is down to %.0f%% strength.", t.Name, state.PlayerName, state.Virus.Strength*100)
+}
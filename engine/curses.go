@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ===== CURSES =====
+//
+// With the curses rule, an attempt on a red herring costs more than the attempt: the
+// virus is cursed with one of curses at random, and the fun fact is only consolation.
+// A curse is a TimedEffect, so it runs out like any other effect; cast again while it
+// is on, it starts over rather than stacking.
+//
+//	blindness  every infection chance is hidden for the rest of the day
+//	weakness   the virus is at ×0.9 strength for two days
+
+// Curse is one setback a red herring can bring.
+type Curse struct {
+	Name        string
+	Icon        string
+	Description string
+	Days        int
+	// StrengthFactor scales the virus strength while the curse is on; Blind hides
+	// every infection chance.
+	StrengthFactor float64
+	Blind          bool
+}
+
+var Curses = []Curse{
+	{Name: "Blindness", Icon: "🕶", Description: "infection chances are hidden", Days: 1, StrengthFactor: 1, Blind: true},
+	{Name: "Weakness", Icon: "🥀", Description: "the virus is at ×0.90 strength", Days: 2, StrengthFactor: 0.9},
+}
+
+// castCurse curses the virus after an attempt on a red herring, and describes it.
+func castCurse(state *GameState) string {
+	c := Curses[state.RNG.Intn(len(Curses))]
+	name := c.Icon + " " + c.Name
+	state.Effects = slices.DeleteFunc(state.Effects, func(e TimedEffect) bool { return e.Name == name })
+	until := state.CurrentDay + c.Days
+	state.Effects = append(state.Effects, TimedEffect{Name: name, StrengthFactor: c.StrengthFactor, Blind: c.Blind, Until: until})
+	return fmt.Sprintf("%s Cursed with %s: %s until day %d.", c.Icon, strings.ToLower(c.Name), c.Description, until)
+}
+
+// Blinded reports whether a curse hides the infection chances.
+func Blinded(state *GameState) bool {
+	return slices.ContainsFunc(state.Effects, func(e TimedEffect) bool { return e.Blind })
+}
+
+// ChanceText formats chance for the board, or hides it while the virus is blinded.
+func ChanceText(state *GameState, chance float64) string {
+	if Blinded(state) {
+		return "??%"
+	}
+	return fmt.Sprintf("%.0f%%", chance*100)
+}
+
+// CurseIcons are the status icons of the active curses, e.g. "🕶 🥀", or "".
+func CurseIcons(state *GameState) string {
+	var icons []string
+	for _, c := range Curses {
+		name := c.Icon + " " + c.Name
+		if slices.ContainsFunc(state.Effects, func(e TimedEffect) bool { return e.Name == name }) {
+			icons = append(icons, c.Icon)
+		}
+	}
+	return strings.Join(icons, " ")
+}
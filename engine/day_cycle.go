@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"go.starlark.net/starlark"
+)
+
+// ===== DAY CYCLE =====
+
+type DayPhase int
+
+const (
+	PhaseMorning DayPhase = iota
+	PhaseAfternoon
+	PhaseNight
+)
+
+const (
+	ActivityDiurnal   = "Diurnal"
+	ActivityNocturnal = "Nocturnal"
+)
+
+func (p DayPhase) String() string {
+	switch p {
+	case PhaseMorning:
+		return "Morning"
+	case PhaseAfternoon:
+		return "Afternoon"
+	default:
+		return "Night"
+	}
+}
+
+func (p DayPhase) Icon() string {
+	switch p {
+	case PhaseMorning:
+		return "🌅"
+	case PhaseAfternoon:
+		return "☀️"
+	default:
+		return "🌙"
+	}
+}
+
+func (p DayPhase) IsDay() bool {
+	return p != PhaseNight
+}
+
+// ActiveDuring reports whether the animal is out and about in the given phase.
+// Animals without an ActivityPeriod are active around the clock.
+func (a *Animal) ActiveDuring(p DayPhase) bool {
+	switch a.ActivityPeriod {
+	case ActivityDiurnal:
+		return p.IsDay()
+	case ActivityNocturnal:
+		return !p.IsDay()
+	default:
+		return true
+	}
+}
+
+// AdvancePhase moves the clock forward one phase, rolling over into the next day after night.
+func AdvancePhase(state *GameState) {
+	if state.Phase == PhaseNight {
+		startNextDay(state)
+		return
+	}
+	state.Phase++
+	Events.Publish(runEvent(state, EventPhase, ""))
+}
+
+// startNextDay skips the rest of the current day, e.g. while a new infection incubates overnight.
+func startNextDay(state *GameState) {
+	state.Phase = PhaseMorning
+	state.CurrentDay++
+	expireEffects(state)
+	settleMoods(state)
+	report := append(immuneResponse(state), passiveSpread(state)...)
+	state.DayReport = append(report, runPopulationDay(state)...)
+	runHook(state, "onDayStart", starlark.MakeInt(state.CurrentDay))
+	Events.Publish(runEvent(state, EventPhase, ""))
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ===== EVENT BUS =====
+
+// Event kinds published on the bus.
+const (
+	// EventSound is published whenever a sound effect or music track starts; Detail is
+	// the audio file path.
+	EventSound = "sound"
+	// EventOutcome is published for every resolved attempt; Detail is the target's name
+	// and Magnitude grades what happened.
+	EventOutcome = "outcome"
+	// EventRunStarted is published when patient zero is infected; Detail is its name.
+	EventRunStarted = "run_started"
+	// EventRunEnded is published when a run is won or lost; Detail is the final host,
+	// and Won and Score give the result.
+	EventRunEnded = "run_ended"
+	// EventPhase is published whenever the clock moves on to a new phase of the day.
+	EventPhase = "phase"
+	// EventRecovered is published when an infected animal recovers under the immune
+	// response rule; Detail is its name.
+	EventRecovered = "recovered"
+)
+
+type Event struct {
+	Kind   string
+	Detail string
+
+	// Magnitude grades an EventOutcome.
+	Magnitude Magnitude
+	// Sound is the target's own cue for an EventOutcome, if the map gives one.
+	Sound string
+	// Caption replaces the caption derived from Detail for an EventSound.
+	Caption string
+
+	// Won and Score describe the result for an EventRunEnded.
+	Won   bool
+	Score int
+	// Bot marks events from a run the simulation bot plays rather than the player.
+	Bot bool
+
+	// Day, Phase, Attempts and Host are where the run stands once the event happened.
+	Day      int
+	Phase    DayPhase
+	Attempts int
+	Host     string
+}
+
+// Magnitude grades an attempt's outcome, from a plain miss to winning the run.
+type Magnitude int
+
+const (
+	MagnitudeMiss Magnitude = iota
+	// MagnitudeCloseCall is a failure against one of the top two levels.
+	MagnitudeCloseCall
+	MagnitudeDefenseBroken
+	MagnitudeSameLevel
+	MagnitudeEvolution
+	MagnitudeApex
+)
+
+// EventBus fans events out to any number of subscribers, so feedback channels (audio,
+// captions, logs) can be added without touching the code that raises them.
+type EventBus struct {
+	mu   sync.Mutex
+	subs map[string][]func(Event)
+
+	// depth counts Publish calls still delivering; published counts all of them.
+	depth     atomic.Int64
+	published atomic.Int64
+}
+
+var Events = &EventBus{subs: map[string][]func(Event){}}
+
+func (b *EventBus) Subscribe(kind string, fn func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[kind] = append(b.subs[kind], fn)
+}
+
+// Publish calls each subscriber of ev.Kind synchronously, in subscription order.
+func (b *EventBus) Publish(ev Event) {
+	b.depth.Add(1)
+	defer b.depth.Add(-1)
+	b.published.Add(1)
+
+	b.mu.Lock()
+	subs := append([]func(Event){}, b.subs[ev.Kind]...)
+	b.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(ev)
+	}
+}
+
+// Depth is the number of events currently being delivered.
+func (b *EventBus) Depth() int64 { return b.depth.Load() }
+
+// TakePublished returns how many events were published since the last call.
+func (b *EventBus) TakePublished() int64 { return b.published.Swap(0) }
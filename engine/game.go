@@ -0,0 +1,479 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+)
+
+// ===== GAME RULES =====
+//
+// The rules below are shared by the Fyne screens and the batch CLI, so they must not
+// touch any UI: they mutate GameState and report what happened.
+
+type OutcomeKind int
+
+const (
+	OutcomeUnavailable OutcomeKind = iota
+	OutcomeRedHerring
+	OutcomeResisted
+	OutcomeDefenseBroken
+	OutcomeInfected
+	// OutcomeLatched is a successful first roll under the two-stage rule; see latch.go.
+	OutcomeLatched
+)
+
+func (k OutcomeKind) String() string {
+	switch k {
+	case OutcomeRedHerring:
+		return "red_herring"
+	case OutcomeResisted:
+		return "resisted"
+	case OutcomeDefenseBroken:
+		return "defense_broken"
+	case OutcomeInfected:
+		return "infected"
+	case OutcomeLatched:
+		return "latched"
+	default:
+		return "unavailable"
+	}
+}
+
+type InfectOutcome struct {
+	Kind OutcomeKind
+	// Reason explains an OutcomeUnavailable.
+	Reason string
+	// Phase and PhaseIndex describe the defense broken by an OutcomeDefenseBroken.
+	Phase      *ResistancePhase
+	PhaseIndex int
+	// Consequence describes what a resisting target did afterwards, or the curse a red
+	// herring cast, if anything.
+	Consequence string
+	// Crit describes a critical success or failure, if the attempt was one; see crits.go.
+	Crit string
+	// Won is set when the infection reached the top level.
+	Won bool
+	// Lost is set when the attempt spent the last of the budget without winning, or
+	// left the run Stranded.
+	Lost bool
+}
+
+var ErrRedHerringStarter = errors.New("red herrings cannot be patient zero")
+
+// StarterLevel is the level patient zero is chosen from: the map's Config.StarterLevel,
+// or 1.
+func StarterLevel(state *GameState) int {
+	if lvl := state.MapConfig.StarterLevel; lvl > 0 {
+		return lvl
+	}
+	return 1
+}
+
+// StarterCandidates lists the animals at the starter level, sorted by name.
+func StarterCandidates(state *GameState) []*Animal {
+	var out []*Animal
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; a.Level == StarterLevel(state) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// CheckStarters explains why a map offers no valid patient zero, or returns nil.
+func CheckStarters(state *GameState) error {
+	if len(state.Animals) == 0 {
+		return fmt.Errorf("the map %s has no animals", state.MapPath)
+	}
+	lvl := StarterLevel(state)
+	candidates := StarterCandidates(state)
+	if len(candidates) == 0 {
+		return fmt.Errorf("the map has no level %d animals to start from", lvl)
+	}
+	for _, a := range candidates {
+		if !a.RedHerring {
+			return nil
+		}
+	}
+	return fmt.Errorf("every level %d animal is a red herring, so there is no patient zero", lvl)
+}
+
+// ChooseStarter infects a as patient zero and starts the clock.
+func ChooseStarter(state *GameState, a *Animal) error {
+	if a.RedHerring {
+		return ErrRedHerringStarter
+	}
+	if lvl := StarterLevel(state); a.Level != lvl {
+		return fmt.Errorf("%s is level %d; patient zero must be level %d", a.Name, a.Level, lvl)
+	}
+	if state.Rules.WildGenetics {
+		applyWildGenetics(state)
+	}
+
+	state.PlayerName = a.Name
+	state.Starter = a.Name
+	a.Infected = true
+	addHost(state, a)
+	state.Stats.StartTime = time.Now()
+	state.Stats.Clock.Start()
+	Events.Publish(runEvent(state, EventRunStarted, a.Name))
+	recordStep(state, "start "+a.Name, "started")
+	return nil
+}
+
+// IsCandidateTarget reports whether a shows up on the board at all this turn.
+func IsCandidateTarget(state *GameState, a *Animal) bool {
+	player := state.Animals[state.PlayerName]
+	up := a.Level == player.Level+1 || (a.Level > player.Level && PreysOn(a, player))
+	if a.Infected || (a.Level != player.Level && !up) || !WithinReach(player, a) {
+		return false
+	}
+	return a.IsPresent(SeasonForDay(state.CurrentDay))
+}
+
+// WithinReach reports whether host meets a in the food web: a is one of host's
+// contacts or lives in the same place. A host with no contacts listed reaches every
+// animal, leaving level alone to decide.
+func WithinReach(host, a *Animal) bool {
+	if len(host.Contacts) == 0 {
+		return true
+	}
+	if _, ok := host.Contacts.Weight(a.Name); ok {
+		return true
+	}
+	return host.Location != "" && a.Location == host.Location
+}
+
+// CandidateTargets lists the animals on the board, sorted by name.
+func CandidateTargets(state *GameState) []*Animal {
+	var out []*Animal
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; IsCandidateTarget(state, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// BlockedReason explains why a candidate cannot be attempted right now, or returns "".
+func BlockedReason(state *GameState, a *Animal) string {
+	season := SeasonForDay(state.CurrentDay)
+
+	if state.Vaccinated[a.Name] {
+		return "💉 Vaccinated"
+	}
+	if day, ok := immuneSince(state, a); ok {
+		return fmt.Sprintf("🧬 Immune since day %d", day)
+	}
+	if host := state.Animals[state.PlayerName]; host != nil && Relation(host, a) == RelationRival {
+		return "⚔ Rival of " + host.Name
+	}
+	if w, ok := isWary(state, a); ok {
+		if w.Fled {
+			return "🏃 Relocating"
+		}
+		return fmt.Sprintf("😠 Wary (%d days)", w.Until-state.CurrentDay)
+	}
+	if days, ok := OnCooldown(state, a); ok {
+		return fmt.Sprintf("⏳ Cooldown (%dd)", days)
+	}
+	if steeringSpread(state) {
+		return "🎯 Steering the spread today"
+	}
+	if reason := takeoverBlocked(state, a); reason != "" {
+		return reason
+	}
+	if a.IsHibernating(season) {
+		return season.Icon() + " Hibernating"
+	}
+	if !a.ActiveDuring(state.Phase) {
+		if a.ActivityPeriod == ActivityNocturnal {
+			return "💤 Active at night"
+		}
+		return "💤 Active by day"
+	}
+	if phase, _ := CurrentResistancePhase(state, a); phase != nil {
+		if missing := missingRequirements(state, a, phase); missing != "" {
+			return "Needs " + missing
+		}
+	}
+	return ""
+}
+
+// BaseChance is the chance before map modifiers: the seasonal rate scaled by strength,
+// by the animal's mood, by an infected symbiote and, with contact weights on, by the
+// closest infected contact.
+func BaseChance(state *GameState, a *Animal) float64 {
+	return math.Min(1, a.SeasonalRate(SeasonForDay(state.CurrentDay))*VirusStrength(state)*MoodFactor(state, a)*ContactFactor(state, a)*SymbioteFactor(state, a)*mutationFactor(state, a))
+}
+
+// InfectionChance is the probability that an attempt on a succeeds right now, for the
+// stage of infection it is at and with any armed mutation burst.
+func InfectionChance(state *GameState, a *Animal) float64 {
+	return math.Min(1, stageChance(state, a, applyModifiers(state, a, BaseChance(state, a)))*burstFactor(state))
+}
+
+// AttemptInfection spends one attempt on t and resolves it.
+func AttemptInfection(state *GameState, t *Animal) InfectOutcome {
+	if AttemptsLeft(state) == 0 {
+		return InfectOutcome{Kind: OutcomeUnavailable, Reason: "no attempts left"}
+	}
+	from := state.Animals[state.PlayerName]
+	day := state.CurrentDay
+	out := resolveAttempt(state, t)
+	if out.Kind == OutcomeUnavailable {
+		return out
+	}
+	// Whatever the outcome, a new day brings passive spread, which can win the run.
+	if state.CurrentDay != day && HasWon(state) {
+		out.Won = true
+	}
+	if !out.Won && (AttemptsLeft(state) == 0 || Stranded(state)) {
+		out.Lost = true
+	}
+	recordStep(state, "infect "+t.Name, out.Kind.String())
+	publishOutcome(state, from, t, out)
+	if out.Won || out.Lost {
+		ev := runEvent(state, EventRunEnded, state.PlayerName)
+		ev.Won, ev.Score = out.Won, CalculateScore(state)
+		Events.Publish(ev)
+	}
+	return out
+}
+
+// WaitOut lets a phase pass without an attempt. It reports whether the run was won
+// meanwhile, which passive spread can do overnight, or lost because it is Stranded.
+func WaitOut(state *GameState) (won, lost bool) {
+	AdvancePhase(state)
+	recordStep(state, "wait", "waited")
+	won = HasWon(state)
+	lost = !won && Stranded(state)
+	if !won && !lost {
+		return false, false
+	}
+	ev := runEvent(state, EventRunEnded, state.PlayerName)
+	ev.Won, ev.Score = won, CalculateScore(state)
+	Events.Publish(ev)
+	return won, lost
+}
+
+// publishOutcome grades out and announces it, so feedback such as audio stingers is
+// chosen by magnitude rather than by whoever made the attempt.
+func publishOutcome(state *GameState, from, t *Animal, out InfectOutcome) {
+	ev := runEvent(state, EventOutcome, t.Name)
+	switch out.Kind {
+	case OutcomeRedHerring:
+		ev.Magnitude = MagnitudeMiss
+	case OutcomeResisted:
+		ev.Magnitude, ev.Sound = MagnitudeMiss, t.Sounds.Resist
+		if t.Level > StarterLevel(state) && t.Level >= state.MaxLevel-1 {
+			ev.Magnitude = MagnitudeCloseCall
+		}
+	case OutcomeDefenseBroken, OutcomeLatched:
+		ev.Magnitude = MagnitudeDefenseBroken
+	case OutcomeInfected:
+		ev.Magnitude, ev.Sound = MagnitudeSameLevel, t.Sounds.Evolve
+		if t.Level > from.Level {
+			ev.Magnitude = MagnitudeEvolution
+		}
+		if out.Won {
+			ev.Magnitude, ev.Sound = MagnitudeApex, ""
+		}
+	}
+	Events.Publish(ev)
+}
+
+// runEvent is an event of the given kind stamped with where the run stands.
+func runEvent(state *GameState, kind, detail string) Event {
+	return Event{
+		Kind:     kind,
+		Detail:   detail,
+		Bot:      state.BotPlayed,
+		Day:      state.CurrentDay,
+		Phase:    state.Phase,
+		Attempts: state.Stats.Attempts,
+		Host:     state.PlayerName,
+	}
+}
+
+func resolveAttempt(state *GameState, t *Animal) InfectOutcome {
+	if !IsCandidateTarget(state, t) {
+		return InfectOutcome{Kind: OutcomeUnavailable, Reason: t.Name + " is not a valid target"}
+	}
+	if reason := BlockedReason(state, t); reason != "" {
+		return InfectOutcome{Kind: OutcomeUnavailable, Reason: reason}
+	}
+
+	state.Stats.Attempts++
+	player := state.Animals[state.PlayerName]
+	when := fmt.Sprintf("Day %d %s", state.CurrentDay, state.Phase)
+
+	if t.RedHerring {
+		state.LastInteraction[t.Name] = when + ": red herring"
+		out := InfectOutcome{Kind: OutcomeRedHerring}
+		if state.Rules.Curses {
+			out.Consequence = castCurse(state)
+		}
+		return out
+	}
+
+	mercy := MercyApplies(state)
+	state.MercyUsed = true
+	chance := InfectionChance(state, t)
+	burst := spendBurst(state)
+
+	if !mercy && state.RNG.Float64() >= chance {
+		out := InfectOutcome{Kind: OutcomeResisted, Consequence: applyFailureConsequence(state, t)}
+		if _, ok := LatchedOn(state, t); ok {
+			delete(state.Latched, t.Name)
+			out.Consequence = strings.TrimSpace("🪝 " + t.Name + " shook the virus off. " + out.Consequence)
+		}
+		if burst {
+			out.Consequence = strings.TrimSpace(out.Consequence + "\n" + burstBackfire(state))
+		}
+		state.LastInteraction[t.Name] = when + ": resisted"
+		state.FailStreak[t.Name]++
+		alarm(state, t)
+		out.Crit = rollCritFailure(state, t)
+		if state.Rules.RetryCooldown {
+			state.Cooldowns[t.Name] = state.CurrentDay + CooldownDays
+		}
+		AdvancePhase(state)
+		return out
+	}
+
+	// A success ends the streak, and any advice dismissed for it.
+	delete(state.FailStreak, t.Name)
+	delete(state.AdviceDismissed, t.Name)
+
+	if phase, i := CurrentResistancePhase(state, t); phase != nil {
+		state.BrokenPhases[t.Name]++
+		state.LastInteraction[t.Name] = when + ": " + phase.Name + " broken"
+		startNextDay(state)
+		return InfectOutcome{Kind: OutcomeDefenseBroken, Phase: phase, PhaseIndex: i}
+	}
+
+	if needsLatch(state, t) {
+		state.Latched[t.Name] = state.CurrentDay
+		state.LastInteraction[t.Name] = when + ": latched"
+		AdvancePhase(state)
+		return InfectOutcome{Kind: OutcomeLatched}
+	}
+	delete(state.Latched, t.Name)
+
+	t.Infected = true
+	delete(state.Moods, t.Name)
+	alarmContacts(state, t)
+	state.LastInteraction[t.Name] = when + ": infected"
+	// t is the host from the morning on, so the day's immune response cannot cure it.
+	state.PlayerName = t.Name
+	startNextDay(state)
+
+	if t.Level > player.Level {
+		state.Stats.NextLevelInfections++
+	} else {
+		state.Stats.SameLevelInfections++
+	}
+	earnMutationPoints(state, t.Level > player.Level)
+	addHost(state, t)
+	state.Transmissions = append(state.Transmissions, Transmission{From: player.Name, To: t.Name, Day: state.CurrentDay})
+	crit := rollCritSuccess(state, t)
+	runHook(state, "onInfectionSuccess", starlark.String(t.Name), starlark.String(player.Name))
+
+	return InfectOutcome{Kind: OutcomeInfected, Crit: crit, Won: HasWon(state)}
+}
+
+// InfectedShare is the fraction of non-red-herring animals currently infected.
+func InfectedShare(state *GameState) float64 {
+	total, infected := 0, 0
+	for _, a := range state.Animals {
+		if a.RedHerring {
+			continue
+		}
+		total++
+		if a.Infected {
+			infected++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(infected) / float64(total)
+}
+
+// VictoryProgress is how close the run is to its win condition, from 0 to 1.
+func VictoryProgress(state *GameState) float64 {
+	if state.Rules.OutbreakPercent > 0 {
+		p := InfectedShare(state) * 100 / float64(state.Rules.OutbreakPercent)
+		if p > 1 {
+			p = 1
+		}
+		return p
+	}
+	host := state.Animals[state.PlayerName]
+	if host == nil || state.MaxLevel == 0 {
+		return 0
+	}
+	return float64(host.Level) / float64(state.MaxLevel)
+}
+
+func HasWon(state *GameState) bool {
+	if state.Rules.OutbreakPercent > 0 {
+		return InfectedShare(state)*100 >= float64(state.Rules.OutbreakPercent)
+	}
+	host := state.Animals[state.PlayerName]
+	return host != nil && host.Level == state.MaxLevel
+}
+
+// Stranded reports whether a run that is not won can never infect another animal:
+// none the host could ever attempt, and none that passive spread could reach, whatever
+// the season, the time of day or any wariness. Nothing is left to change the board but
+// recoveries, so the run is lost. An outbreak run stalls like this once the host is at
+// the top and too few animals are left within reach to make up the share.
+func Stranded(state *GameState) bool {
+	host := state.Animals[state.PlayerName]
+	if host == nil || HasWon(state) {
+		return false
+	}
+	for _, a := range state.Animals {
+		if a.Infected || a.RedHerring || state.Vaccinated[a.Name] || IsImmune(state, a) {
+			continue
+		}
+		if canEverAttempt(host, a) || (state.Rules.PassiveSpread && canEverSpread(state, host, a)) {
+			return false
+		}
+	}
+	return true
+}
+
+// canEverAttempt is IsCandidateTarget and the lasting part of BlockedReason: the level
+// and reach that a target needs and the host's rivals, leaving out what passes by
+// itself.
+func canEverAttempt(host, a *Animal) bool {
+	up := a.Level == host.Level+1 || (a.Level > host.Level && PreysOn(a, host))
+	return (a.Level == host.Level || up) && WithinReach(host, a) && Relation(host, a) != RelationRival
+}
+
+// canEverSpread is SpreadSource without the season: a is at or below the host's level,
+// has no defense left to break and has an infected contact.
+func canEverSpread(state *GameState, host, a *Animal) bool {
+	if a.Level > host.Level {
+		return false
+	}
+	if phase, _ := CurrentResistancePhase(state, a); phase != nil {
+		return false
+	}
+	for _, other := range state.Animals {
+		if other != a && other.Infected {
+			if _, ok := EdgeWeight(a, other); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
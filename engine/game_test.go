@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// strandedMap is a two-level park: the fox and the wolf share the meadow with the
+// mouse, and the vole and the beetle keep to the marsh, out of the fox's reach.
+const strandedMap = `{
+  "Level1": [
+    {"Name": "Mouse", "Level": 1, "Mobility": "Walk", "Intelligence": 1, "InfectionRate": 0.5, "Location": "Meadow", "Contacts": ["Fox"]},
+    {"Name": "Vole", "Level": 1, "Mobility": "Walk", "Intelligence": 1, "InfectionRate": 0.5, "Location": "Marsh"},
+    {"Name": "Beetle", "Level": 1, "Mobility": "Walk", "Intelligence": 1, "InfectionRate": 0.5, "Location": "Marsh"}
+  ],
+  "Level2": [
+    {"Name": "Fox", "Level": 2, "Mobility": "Walk", "Intelligence": 2, "InfectionRate": 0.5, "Location": "Meadow", "Contacts": ["Mouse"]},
+    {"Name": "Wolf", "Level": 2, "Mobility": "Walk", "Intelligence": 2, "InfectionRate": 0.5, "Location": "Meadow"}
+  ]
+}`
+
+// TestStrandedOutbreak plays an outbreak run to the top level with too little of the
+// park in reach to make up the share, and checks that it ends as a loss.
+func TestStrandedOutbreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stranded.json")
+	if err := os.WriteFile(path, []byte(strandedMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state := NewGameState(path)
+	state.Rules = RulesConfig{OutbreakPercent: 75, PassiveSpread: true}
+	SeedRun(state, 1)
+	if err := ChooseStarter(state, state.Animals["Mouse"]); err != nil {
+		t.Fatal(err)
+	}
+	state.Animals["Fox"].Infected = true
+	state.PlayerName = "Fox"
+
+	if Stranded(state) {
+		t.Fatal("stranded with the wolf still in reach")
+	}
+
+	// The wolf leaves the meadow: 2 of 5 animals are infected and no other can be.
+	state.Animals["Wolf"].Location = "Ridge"
+	if !Stranded(state) {
+		t.Fatal("not stranded with nothing left in reach")
+	}
+	won, lost := WaitOut(state)
+	if won || !lost {
+		t.Errorf("WaitOut = won %v, lost %v; want a loss", won, lost)
+	}
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ===== WILD GENETICS =====
+
+// GeneticsRange bounds how far wild genetics may move an animal's stats: the rate is
+// scaled by up to ±RateSpread and Intelligence shifted by up to ±IntelligenceSpread.
+// Set map-wide under Config.Genetics and overridden per animal with "Genetics".
+type GeneticsRange struct {
+	RateSpread         float64 `json:"RateSpread"`
+	IntelligenceSpread int     `json:"IntelligenceSpread"`
+}
+
+// GeneticShift records what wild genetics did to one animal, for display.
+type GeneticShift struct {
+	BaseRate         float64
+	BaseIntelligence int
+}
+
+func geneticsRangeFor(state *GameState, a *Animal) GeneticsRange {
+	if a.Genetics != nil {
+		return *a.Genetics
+	}
+	return state.MapConfig.Genetics
+}
+
+// applyWildGenetics perturbs every animal once at the start of a run. Animals are visited
+// in name order so the result depends only on the seed.
+func applyWildGenetics(state *GameState) {
+	for _, name := range SortedAnimalNames(state) {
+		a := state.Animals[name]
+		r := geneticsRangeFor(state, a)
+		if r.RateSpread <= 0 && r.IntelligenceSpread <= 0 {
+			continue
+		}
+
+		state.Genetics[name] = GeneticShift{BaseRate: a.InfectionRate, BaseIntelligence: a.Intelligence}
+
+		if r.RateSpread > 0 {
+			a.InfectionRate *= 1 + (state.RNG.Float64()*2-1)*r.RateSpread
+			if a.InfectionRate > 1 {
+				a.InfectionRate = 1
+			}
+			if a.InfectionRate < 0.01 {
+				a.InfectionRate = 0.01
+			}
+		}
+		if r.IntelligenceSpread > 0 {
+			a.Intelligence += state.RNG.Intn(2*r.IntelligenceSpread+1) - r.IntelligenceSpread
+			if a.Intelligence < 1 {
+				a.Intelligence = 1
+			}
+		}
+	}
+}
+
+// carryGenetics moves what wild genetics did to old onto fresh, a copy of the same
+// animal reloaded from the map, and takes fresh's stats as the new base.
+func carryGenetics(state *GameState, old, fresh *Animal) {
+	shift, ok := state.Genetics[old.Name]
+	if !ok {
+		return
+	}
+	state.Genetics[old.Name] = GeneticShift{BaseRate: fresh.InfectionRate, BaseIntelligence: fresh.Intelligence}
+	if shift.BaseRate > 0 {
+		fresh.InfectionRate = math.Min(1, math.Max(0.01, fresh.InfectionRate*old.InfectionRate/shift.BaseRate))
+	}
+	fresh.Intelligence = max(1, fresh.Intelligence+old.Intelligence-shift.BaseIntelligence)
+}
+
+// GeneticsNote describes an animal's perturbed stats, or "" if they were left alone.
+func GeneticsNote(state *GameState, a *Animal) string {
+	shift, ok := state.Genetics[a.Name]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("🧬 Wild genetics: rate %.0f%% → %.0f%%, intelligence %d → %d",
+		shift.BaseRate*100, a.InfectionRate*100, shift.BaseIntelligence, a.Intelligence)
+}
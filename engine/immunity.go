@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ===== IMMUNE RESPONSE =====
+//
+// With the immune response rule, infected animals fight the virus off. Each morning
+// every infected animal but the host clears it with a chance of RecoveryPerIntelligence
+// per point of Intelligence, at most MaxRecoveryChance, and is immune for the rest of
+// the run: no attempt, spread or critical success infects it again. An outbreak left
+// behind the host shrinks while the player dawdles, so the run rewards momentum.
+
+const (
+	RecoveryPerIntelligence = 0.015
+	MaxRecoveryChance       = 0.2
+)
+
+// recoveryChance is the chance a clears the virus on any one morning.
+func recoveryChance(a *Animal) float64 {
+	return math.Min(MaxRecoveryChance, float64(max(a.Intelligence, 1))*RecoveryPerIntelligence)
+}
+
+// immuneSince reports the day a recovered and became immune.
+func immuneSince(state *GameState, a *Animal) (int, bool) {
+	day, ok := state.Immune[a.Name]
+	return day, ok
+}
+
+// IsImmune reports whether a has recovered and can never be infected again.
+func IsImmune(state *GameState, a *Animal) bool {
+	_, ok := state.Immune[a.Name]
+	return ok
+}
+
+// immuneResponse rolls the morning's recoveries and reports them; it runs at the start
+// of each day, before passive spread, so a recovered animal cannot catch it back.
+func immuneResponse(state *GameState) []string {
+	if !state.Rules.Immunity || state.PlayerName == "" {
+		return nil
+	}
+	var report []string
+	when := fmt.Sprintf("Day %d %s", state.CurrentDay, state.Phase)
+	for _, name := range SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if !a.Infected || a.RedHerring || name == state.PlayerName {
+			continue
+		}
+		if state.RNG.Float64() >= recoveryChance(a) {
+			continue
+		}
+		a.Infected = false
+		state.Immune[name] = state.CurrentDay
+		state.LastInteraction[name] = when + ": recovered"
+		Events.Publish(runEvent(state, EventRecovered, name))
+		report = append(report, "🩹 "+name+" recovered and is immune")
+	}
+	return report
+}
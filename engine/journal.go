@@ -0,0 +1,28 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ===== JOURNAL =====
+
+// JournalNote is a note the player wrote during the run, stamped with the game time.
+type JournalNote struct {
+	Day   int    `json:"day"`
+	Phase string `json:"phase"`
+	Text  string `json:"text"`
+}
+
+func (n JournalNote) String() string {
+	return fmt.Sprintf("Day %d %s: %s", n.Day, n.Phase, n.Text)
+}
+
+func AddJournalNote(state *GameState, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	state.Journal = append(state.Journal, JournalNote{Day: state.CurrentDay, Phase: state.Phase.String(), Text: text})
+	recordStep(state, "note "+text, "noted")
+}
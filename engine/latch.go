@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"math"
+)
+
+// ===== TWO-STAGE INFECTION =====
+//
+// With the two-stage rule, taking a host needs two successful rolls once its defenses
+// are down. The first latches the virus on: it is likelier than a normal roll and only
+// costs a phase of the day. The takeover can only be tried from the next day on and is
+// harder; failing it shakes the virus off, so the host has to be latched again.
+
+const (
+	// LatchChanceTries is how many normal rolls a latch is worth: the latch succeeds if
+	// any of them would.
+	LatchChanceTries = 2
+	// TakeoverFactor scales the chance of the takeover roll.
+	TakeoverFactor = 0.75
+)
+
+// LatchedOn reports whether the virus has latched onto a, and on which day.
+func LatchedOn(state *GameState, a *Animal) (day int, ok bool) {
+	day, ok = state.Latched[a.Name]
+	return day, ok
+}
+
+// needsLatch reports whether the next successful roll on a only latches onto it.
+func needsLatch(state *GameState, a *Animal) bool {
+	if !state.Rules.TwoStage {
+		return false
+	}
+	if phase, _ := CurrentResistancePhase(state, a); phase != nil {
+		return false
+	}
+	_, ok := LatchedOn(state, a)
+	return !ok
+}
+
+// stageChance adjusts a roll's chance for the stage the attempt is at: a latch gets
+// LatchChanceTries goes at chance, a takeover is scaled by TakeoverFactor.
+func stageChance(state *GameState, a *Animal, chance float64) float64 {
+	if !state.Rules.TwoStage {
+		return chance
+	}
+	if needsLatch(state, a) {
+		return 1 - math.Pow(1-chance, LatchChanceTries)
+	}
+	if _, ok := LatchedOn(state, a); ok {
+		return chance * TakeoverFactor
+	}
+	return chance
+}
+
+// takeoverBlocked explains why a latched host cannot be taken over yet, or returns "".
+func takeoverBlocked(state *GameState, a *Animal) string {
+	if day, ok := LatchedOn(state, a); ok && day >= state.CurrentDay {
+		return "🪝 Latched (take over tomorrow)"
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ===== HOST LINEAGE =====
+//
+// The lineage is the chain of hosts the virus has lived in this run, patient zero
+// first. The header shows its tail as a breadcrumb; the win and loss screens show it
+// whole, with the day each host was taken.
+
+// breadcrumbHosts is how many of the latest hosts the header breadcrumb shows.
+const breadcrumbHosts = 5
+
+// HostStep is one host of the lineage.
+type HostStep struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+	Day   int    `json:"day"`
+}
+
+// addHost records a as the newest host.
+func addHost(state *GameState, a *Animal) {
+	state.Lineage = append(state.Lineage, HostStep{Name: a.Name, Level: a.Level, Day: state.CurrentDay})
+}
+
+// LineageBreadcrumb is the tail of the lineage, e.g. "… → Red Fox → Gray Wolf".
+func LineageBreadcrumb(state *GameState) string {
+	steps := state.Lineage
+	var names []string
+	if len(steps) > breadcrumbHosts {
+		names = append(names, "…")
+		steps = steps[len(steps)-breadcrumbHosts:]
+	}
+	for _, s := range steps {
+		names = append(names, s.Name)
+	}
+	return strings.Join(names, " → ")
+}
+
+// LineageText is the whole lineage, e.g. "Deer Mouse (L1, day 1) → Red Fox (L2, day 3)".
+func LineageText(state *GameState) string {
+	parts := make([]string, len(state.Lineage))
+	for i, s := range state.Lineage {
+		parts[i] = fmt.Sprintf("%s (L%d, day %d)", s.Name, s.Level, s.Day)
+	}
+	return strings.Join(parts, " → ")
+}
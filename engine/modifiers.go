@@ -0,0 +1,554 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ===== CHANCE MODIFIERS =====
+//
+// Map files can list chance modifiers under Config.Modifiers, written in a tiny
+// expression language and applied in order to every infection roll:
+//
+//	"if target.Mobility == 'Fly' and season == 'Winter' then chance*0.5"
+//	"if host.Level < target.Level then chance - 0.05"
+//	"chance * 1.1"
+//
+// An if without an else leaves the chance unchanged when the condition is false. The
+// language has arithmetic (+ - * /), comparisons, and/or/not, parentheses, numbers,
+// 'strings' and true/false, but no loops or calls, so every expression terminates.
+// Names are checked when the map loads:
+//
+//	chance, strength, day, attempts, season, phase
+//	target.<field>, host.<field>  (Name, Species, Level, Mobility, Intelligence,
+//	                               Location, ActivityPeriod, InfectionRate)
+
+// Modifier is one compiled modifier expression.
+type Modifier struct {
+	Source string
+	expr   exprNode
+}
+
+var modifierAnimalFields = []string{"Name", "Species", "Level", "Mobility", "Intelligence", "Location", "ActivityPeriod", "InfectionRate"}
+
+func knownModifierName(name string) bool {
+	switch name {
+	case "chance", "strength", "day", "attempts", "season", "phase":
+		return true
+	}
+	obj, field, ok := strings.Cut(name, ".")
+	if !ok || (obj != "target" && obj != "host") {
+		return false
+	}
+	for _, f := range modifierAnimalFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CompileModifiers parses every source, reporting the first error with its position in
+// the list.
+func CompileModifiers(sources []string) ([]*Modifier, error) {
+	var out []*Modifier
+	for i, src := range sources {
+		m, err := CompileModifier(src)
+		if err != nil {
+			return out, fmt.Errorf("modifier %d (%q): %w", i+1, src, err)
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func CompileModifier(src string) (*Modifier, error) {
+	toks, err := lexModifier(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &modParser{toks: toks}
+	expr, err := p.parseTop()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+	return &Modifier{Source: src, expr: expr}, nil
+}
+
+// applyModifiers runs the map's modifiers, then any weekly mutation, over chance for an
+// attempt on target. A modifier that fails at runtime (e.g. comparing a name to a
+// number) is skipped.
+func applyModifiers(state *GameState, target *Animal, chance float64) float64 {
+	mods := state.Modifiers
+	if w := weeklyModifier(state.Rules.Weekly); w != nil {
+		mods = append(mods[:len(mods):len(mods)], w)
+	}
+	if len(mods) == 0 {
+		return chance
+	}
+	env := modifierEnv{state: state, target: target, host: state.Animals[state.PlayerName]}
+	for _, m := range mods {
+		env.chance = chance
+		v, err := m.expr.eval(env)
+		if err != nil || v.kind != valNumber || math.IsNaN(v.num) || math.IsInf(v.num, 0) {
+			continue
+		}
+		chance = math.Max(0, math.Min(1, v.num))
+	}
+	return chance
+}
+
+// ----- values and environment -----
+
+type valueKind int
+
+const (
+	valNumber valueKind = iota
+	valString
+	valBool
+)
+
+type value struct {
+	kind valueKind
+	num  float64
+	str  string
+	b    bool
+}
+
+func (v value) String() string {
+	switch v.kind {
+	case valString:
+		return "'" + v.str + "'"
+	case valBool:
+		return strconv.FormatBool(v.b)
+	default:
+		return strconv.FormatFloat(v.num, 'g', -1, 64)
+	}
+}
+
+func numberValue(f float64) value { return value{kind: valNumber, num: f} }
+func stringValue(s string) value  { return value{kind: valString, str: s} }
+func boolValue(b bool) value      { return value{kind: valBool, b: b} }
+
+type modifierEnv struct {
+	state        *GameState
+	target, host *Animal
+	chance       float64
+}
+
+func (e modifierEnv) lookup(name string) (value, error) {
+	switch name {
+	case "chance":
+		return numberValue(e.chance), nil
+	case "strength":
+		return numberValue(VirusStrength(e.state)), nil
+	case "day":
+		return numberValue(float64(e.state.CurrentDay)), nil
+	case "attempts":
+		return numberValue(float64(e.state.Stats.Attempts)), nil
+	case "season":
+		return stringValue(SeasonForDay(e.state.CurrentDay).String()), nil
+	case "phase":
+		return stringValue(e.state.Phase.String()), nil
+	}
+
+	obj, field, _ := strings.Cut(name, ".")
+	a := e.target
+	if obj == "host" {
+		a = e.host
+	}
+	if a == nil {
+		return value{}, fmt.Errorf("%s is not set", obj)
+	}
+	switch field {
+	case "Name":
+		return stringValue(a.Name), nil
+	case "Species":
+		return stringValue(a.SpeciesName()), nil
+	case "Level":
+		return numberValue(float64(a.Level)), nil
+	case "Mobility":
+		return stringValue(a.Mobility), nil
+	case "Intelligence":
+		return numberValue(float64(a.Intelligence)), nil
+	case "Location":
+		return stringValue(a.Location), nil
+	case "ActivityPeriod":
+		return stringValue(a.ActivityPeriod), nil
+	case "InfectionRate":
+		return numberValue(a.InfectionRate), nil
+	}
+	return value{}, fmt.Errorf("unknown name %s", name)
+}
+
+// ----- lexer -----
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+func lexModifier(src string) ([]token, error) {
+	var toks []token
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			start := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{tokNumber, string(rs[start:i]), start})
+		case r == '\'' || r == '"':
+			start := i
+			i++
+			for i < len(rs) && rs[i] != r {
+				i++
+			}
+			if i == len(rs) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			toks = append(toks, token{tokString, string(rs[start+1 : i]), start})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_' || rs[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{tokIdent, string(rs[start:i]), start})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(rs) {
+				two = string(rs[i : i+2])
+			}
+			switch two {
+			case "==", "!=", "<=", ">=":
+				toks = append(toks, token{tokOp, two, start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("+-*/<>()", r) {
+				return nil, fmt.Errorf("unexpected %q at %d", r, start)
+			}
+			toks = append(toks, token{tokOp, string(r), start})
+			i++
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(rs)}), nil
+}
+
+// ----- parser -----
+
+type exprNode interface {
+	eval(env modifierEnv) (value, error)
+}
+
+type modParser struct {
+	toks []token
+	i    int
+}
+
+func (p *modParser) peek() token { return p.toks[p.i] }
+
+func (p *modParser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the given operator or keyword.
+func (p *modParser) accept(text string) bool {
+	if t := p.peek(); (t.kind == tokOp || t.kind == tokIdent) && t.text == text {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *modParser) expect(text string) error {
+	if !p.accept(text) {
+		t := p.peek()
+		if t.kind == tokEOF {
+			return fmt.Errorf("expected %q at end", text)
+		}
+		return fmt.Errorf("expected %q at %d, found %q", text, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *modParser) parseTop() (exprNode, error) {
+	if !p.accept("if") {
+		return p.parseOr()
+	}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("then"); err != nil {
+		return nil, err
+	}
+	then, err := p.parseTop()
+	if err != nil {
+		return nil, err
+	}
+	var els exprNode = nameNode("chance")
+	if p.accept("else") {
+		if els, err = p.parseTop(); err != nil {
+			return nil, err
+		}
+	}
+	return ifNode{cond, then, els}, nil
+}
+
+func (p *modParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.accept("or") {
+		var right exprNode
+		if right, err = p.parseAnd(); err == nil {
+			left = logicNode{"or", left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *modParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	for err == nil && p.accept("and") {
+		var right exprNode
+		if right, err = p.parseNot(); err == nil {
+			left = logicNode{"and", left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *modParser) parseNot() (exprNode, error) {
+	if p.accept("not") {
+		x, err := p.parseNot()
+		return notNode{x}, err
+	}
+	return p.parseCompare()
+}
+
+func (p *modParser) parseCompare() (exprNode, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			right, err := p.parseSum()
+			return binaryNode{op, left, right}, err
+		}
+	}
+	return left, nil
+}
+
+func (p *modParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	for err == nil {
+		op := ""
+		if p.accept("+") {
+			op = "+"
+		} else if p.accept("-") {
+			op = "-"
+		} else {
+			break
+		}
+		var right exprNode
+		if right, err = p.parseProduct(); err == nil {
+			left = binaryNode{op, left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *modParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	for err == nil {
+		op := ""
+		if p.accept("*") {
+			op = "*"
+		} else if p.accept("/") {
+			op = "/"
+		} else {
+			break
+		}
+		var right exprNode
+		if right, err = p.parseUnary(); err == nil {
+			left = binaryNode{op, left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *modParser) parseUnary() (exprNode, error) {
+	if p.accept("-") {
+		x, err := p.parseUnary()
+		return binaryNode{"-", literalNode{numberValue(0)}, x}, err
+	}
+	return p.parsePrimary()
+}
+
+func (p *modParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", t.text)
+		}
+		return literalNode{numberValue(f)}, nil
+	case tokString:
+		return literalNode{stringValue(t.text)}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return literalNode{boolValue(t.text == "true")}, nil
+		}
+		if !knownModifierName(t.text) {
+			return nil, fmt.Errorf("unknown name %s", t.text)
+		}
+		return nameNode(t.text), nil
+	case tokOp:
+		if t.text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		}
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
+
+// ----- evaluation -----
+
+type literalNode struct{ v value }
+
+func (n literalNode) eval(modifierEnv) (value, error) { return n.v, nil }
+
+type nameNode string
+
+func (n nameNode) eval(env modifierEnv) (value, error) { return env.lookup(string(n)) }
+
+type notNode struct{ x exprNode }
+
+func (n notNode) eval(env modifierEnv) (value, error) {
+	v, err := evalBool(n.x, env)
+	return boolValue(!v), err
+}
+
+type logicNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n logicNode) eval(env modifierEnv) (value, error) {
+	l, err := evalBool(n.left, env)
+	if err != nil {
+		return value{}, err
+	}
+	if (n.op == "and" && !l) || (n.op == "or" && l) {
+		return boolValue(l), nil
+	}
+	r, err := evalBool(n.right, env)
+	return boolValue(r), err
+}
+
+type ifNode struct{ cond, then, els exprNode }
+
+func (n ifNode) eval(env modifierEnv) (value, error) {
+	c, err := evalBool(n.cond, env)
+	if err != nil {
+		return value{}, err
+	}
+	if c {
+		return n.then.eval(env)
+	}
+	return n.els.eval(env)
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(env modifierEnv) (value, error) {
+	l, err := n.left.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+	r, err := n.right.eval(env)
+	if err != nil {
+		return value{}, err
+	}
+
+	switch n.op {
+	case "==":
+		return boolValue(l == r), nil
+	case "!=":
+		return boolValue(l != r), nil
+	}
+
+	if l.kind != valNumber || r.kind != valNumber {
+		return value{}, fmt.Errorf("%s %s %s: needs numbers", l, n.op, r)
+	}
+	switch n.op {
+	case "+":
+		return numberValue(l.num + r.num), nil
+	case "-":
+		return numberValue(l.num - r.num), nil
+	case "*":
+		return numberValue(l.num * r.num), nil
+	case "/":
+		if r.num == 0 {
+			return value{}, fmt.Errorf("division by zero")
+		}
+		return numberValue(l.num / r.num), nil
+	case "<":
+		return boolValue(l.num < r.num), nil
+	case "<=":
+		return boolValue(l.num <= r.num), nil
+	case ">":
+		return boolValue(l.num > r.num), nil
+	default:
+		return boolValue(l.num >= r.num), nil
+	}
+}
+
+func evalBool(x exprNode, env modifierEnv) (bool, error) {
+	v, err := x.eval(env)
+	if err != nil {
+		return false, err
+	}
+	if v.kind != valBool {
+		return false, fmt.Errorf("%s is not true or false", v)
+	}
+	return v.b, nil
+}
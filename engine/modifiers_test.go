@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWeeklyMutations compiles every built-in weekly mutation and evaluates it for a
+// flying level 5 target by the river, attempted from level 4 on a spring night.
+func TestWeeklyMutations(t *testing.T) {
+	want := map[string]float64{
+		"Grounded":          0.4,
+		"Night Shift":       0.6,
+		"Deep Freeze":       0.5,
+		"River Fever":       0.625,
+		"Underdog":          0.575,
+		"Burrowers' Plague": 0.5,
+		"Slow Start":        0.425,
+	}
+	env := modifierEnv{
+		state:  &GameState{CurrentDay: 3, Phase: PhaseNight, Virus: &Virus{Strength: 1}},
+		target: &Animal{AnimalDef: &AnimalDef{Name: "Bald Eagle", Level: 5, Mobility: "Fly"}, Location: "River"},
+		host:   &Animal{AnimalDef: &AnimalDef{Name: "Gray Wolf", Level: 4, Mobility: "Walk"}, Location: "Valley"},
+		chance: 0.5,
+	}
+	for _, w := range WeeklyMutations {
+		m, err := CompileModifier(w.Modifier)
+		if err != nil {
+			t.Errorf("%s: %v", w.Name, err)
+			continue
+		}
+		v, err := m.expr.eval(env)
+		if err != nil {
+			t.Errorf("%s: %v", w.Name, err)
+			continue
+		}
+		exp, ok := want[w.Name]
+		if !ok {
+			t.Errorf("%s: no expected chance in the table", w.Name)
+			continue
+		}
+		if v.kind != valNumber || math.Abs(v.num-exp) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", w.Name, v, exp)
+		}
+	}
+}
+
+func TestMalformedModifiers(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"chance *",
+		"(chance * 2",
+		"chance * 2)",
+		"if chance > 0.5 chance * 2",
+		"if target.Mobility == 'Fly then chance",
+		"target.Wings * 2",
+		"weather == 'Rain'",
+		"chance # 2",
+		"if then else",
+	} {
+		if _, err := CompileModifier(src); err == nil {
+			t.Errorf("%q compiled", src)
+		}
+	}
+}
+
+// TestModifierRuntimeErrors checks that a modifier that cannot be evaluated leaves the
+// chance alone rather than failing the roll.
+func TestModifierRuntimeErrors(t *testing.T) {
+	mods, err := CompileModifiers([]string{"target.Name * 2", "if target.Level > 'high' then 0 else chance"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &GameState{Animals: map[string]*Animal{}, Virus: &Virus{Strength: 1}, Modifiers: mods}
+	if got := applyModifiers(state, &Animal{AnimalDef: &AnimalDef{Name: "Elk", Level: 3}}, 0.3); got != 0.3 {
+		t.Errorf("got %v, want 0.3", got)
+	}
+}
@@ -0,0 +1,107 @@
+package engine
+
+// ===== ANIMAL MOODS =====
+//
+// Animals are calm until something alarms them. A failed attempt on an animal, or an
+// infection among its contacts, raises its mood one step: calm, then alert, then
+// panicked. An alert animal is watchful and harder to infect; a panicked one is
+// stressed and easier. Moods settle one step every MoodDecayDays.
+
+type Mood int
+
+const (
+	MoodCalm Mood = iota
+	MoodAlert
+	MoodPanicked
+)
+
+const (
+	MoodDecayDays      = 2
+	MoodAlertFactor    = 0.85
+	MoodPanickedFactor = 1.2
+)
+
+func (m Mood) String() string {
+	switch m {
+	case MoodAlert:
+		return "alert"
+	case MoodPanicked:
+		return "panicked"
+	default:
+		return "calm"
+	}
+}
+
+func (m Mood) Icon() string {
+	switch m {
+	case MoodAlert:
+		return "👀"
+	case MoodPanicked:
+		return "😱"
+	default:
+		return "😌"
+	}
+}
+
+// MoodStatus is an animal's mood and the day it last changed.
+type MoodStatus struct {
+	Mood  Mood
+	Since int
+}
+
+func MoodOf(state *GameState, a *Animal) Mood {
+	return state.Moods[a.Name].Mood
+}
+
+// WithMood appends a's mood icon to text, unless a is calm.
+func WithMood(state *GameState, a *Animal, text string) string {
+	if m := MoodOf(state, a); m != MoodCalm {
+		return text + " " + m.Icon()
+	}
+	return text
+}
+
+// MoodFactor scales a's infection chance by its mood.
+func MoodFactor(state *GameState, a *Animal) float64 {
+	switch MoodOf(state, a) {
+	case MoodAlert:
+		return MoodAlertFactor
+	case MoodPanicked:
+		return MoodPanickedFactor
+	}
+	return 1
+}
+
+// alarm raises a's mood one step.
+func alarm(state *GameState, a *Animal) {
+	m := state.Moods[a.Name]
+	if m.Mood < MoodPanicked {
+		m.Mood++
+	}
+	m.Since = state.CurrentDay
+	state.Moods[a.Name] = m
+}
+
+// alarmContacts alarms every uninfected contact of a newly infected animal.
+func alarmContacts(state *GameState, infected *Animal) {
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; a != infected && !a.Infected && IsAdjacent(a, infected) {
+			alarm(state, a)
+		}
+	}
+}
+
+// settleMoods lowers each mood one step once it has lasted MoodDecayDays.
+func settleMoods(state *GameState) {
+	for name, m := range state.Moods {
+		if state.CurrentDay-m.Since < MoodDecayDays {
+			continue
+		}
+		if m.Mood--; m.Mood == MoodCalm {
+			delete(state.Moods, name)
+			continue
+		}
+		m.Since = state.CurrentDay
+		state.Moods[name] = m
+	}
+}
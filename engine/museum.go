@@ -0,0 +1,10 @@
+package engine
+
+// ===== GENEALOGY MUSEUM =====
+
+// Transmission is one edge of the transmission tree: From infected To on Day.
+type Transmission struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Day  int    `json:"day"`
+}
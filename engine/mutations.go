@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ===== MUTATION TREE =====
+//
+// With the mutations rule, every infection the player makes earns mutation points, more
+// for a level up than for a same-level infection. Points are spent on upgrades between
+// days, that is while it is still morning, before the day's first attempt or wait:
+//
+//	airborne  the Airborne mode, which some defenses ask for; flying animals are easier
+//	strength  more virus strength, up to three times
+//	stealth   intelligent animals notice the virus less
+//
+// Airborne and strength change state.Virus directly, so they show up wherever the virus
+// does; the chance bonuses of airborne and stealth are applied by mutationFactor.
+
+const (
+	MutationPointsLevelUp   = 2
+	MutationPointsSameLevel = 1
+
+	airborneMode        = "Airborne"
+	airborneFlyFactor   = 1.2
+	strengthStep        = 0.1
+	strengthMaxLevel    = 3
+	stealthIntelligence = 5
+	stealthFactor       = 1.25
+)
+
+// Upgrade IDs, as typed in batch scripts and recorded inputs.
+const (
+	upgradeAirborne = "airborne"
+	upgradeStrength = "strength"
+	upgradeStealth  = "stealth"
+)
+
+var (
+	ErrMutationsOff    = errors.New("mutations need the mutations rule")
+	ErrNotBetweenDays  = errors.New("upgrades can only be bought in the morning, before the day's first move")
+	ErrNotEnoughPoints = errors.New("not enough mutation points")
+)
+
+// Upgrade is one node of the mutation tree.
+type Upgrade struct {
+	ID          string
+	Name        string
+	Description string
+	Cost        int
+	// MaxLevel is how many times it can be bought.
+	MaxLevel int
+	apply    func(state *GameState)
+}
+
+var Upgrades = []Upgrade{
+	{
+		ID: upgradeAirborne, Name: "Airborne", Cost: 3, MaxLevel: 1,
+		Description: fmt.Sprintf("gain the %s mode; flying animals are ×%.2f easier", airborneMode, airborneFlyFactor),
+		apply: func(state *GameState) {
+			if !state.Virus.HasMode(airborneMode) {
+				state.Virus.Modes = append(state.Virus.Modes, airborneMode)
+			}
+		},
+	},
+	{
+		ID: upgradeStrength, Name: "Virulence", Cost: 2, MaxLevel: strengthMaxLevel,
+		Description: fmt.Sprintf("+%.0f%% virus strength", strengthStep*100),
+		apply:       func(state *GameState) { state.Virus.Strength += strengthStep },
+	},
+	{
+		ID: upgradeStealth, Name: "Stealth", Cost: 3, MaxLevel: 1,
+		Description: fmt.Sprintf("animals of intelligence %d or more are ×%.2f easier", stealthIntelligence, stealthFactor),
+		apply:       func(*GameState) {},
+	},
+}
+
+func FindUpgrade(query string) (Upgrade, error) {
+	for _, u := range Upgrades {
+		if strings.EqualFold(query, u.ID) || strings.EqualFold(query, u.Name) {
+			return u, nil
+		}
+	}
+	return Upgrade{}, fmt.Errorf("no upgrade named %q", query)
+}
+
+// earnMutationPoints credits an infection the player made.
+func earnMutationPoints(state *GameState, levelUp bool) {
+	if !state.Rules.Mutations {
+		return
+	}
+	if levelUp {
+		state.MutationPoints += MutationPointsLevelUp
+	} else {
+		state.MutationPoints += MutationPointsSameLevel
+	}
+}
+
+// BuyUpgrade spends points on the next level of u.
+func BuyUpgrade(state *GameState, u Upgrade) error {
+	switch {
+	case !state.Rules.Mutations:
+		return ErrMutationsOff
+	case state.Phase != PhaseMorning:
+		return ErrNotBetweenDays
+	case state.Upgrades[u.ID] >= u.MaxLevel:
+		return fmt.Errorf("%s is already fully evolved", u.Name)
+	case state.MutationPoints < u.Cost:
+		return ErrNotEnoughPoints
+	}
+	state.MutationPoints -= u.Cost
+	state.Upgrades[u.ID]++
+	u.apply(state)
+	recordStep(state, "upgrade "+u.ID, "evolved")
+	return nil
+}
+
+// mutationFactor scales the chance on a by the upgrades that favor it.
+func mutationFactor(state *GameState, a *Animal) float64 {
+	f := 1.0
+	if state.Upgrades[upgradeAirborne] > 0 && a.Mobility == "Fly" {
+		f *= airborneFlyFactor
+	}
+	if state.Upgrades[upgradeStealth] > 0 && a.Intelligence >= stealthIntelligence {
+		f *= stealthFactor
+	}
+	return f
+}
@@ -0,0 +1,6 @@
+package engine
+
+// ===== NEW GAME PLUS =====
+
+// NGPlusRateFactor scales every infection rate once per NG+ cycle, so difficulty stacks.
+const NGPlusRateFactor = 0.85
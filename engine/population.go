@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ===== POPULATION =====
+
+// PopulationConfig controls births and deaths, set per map under "Config".
+// Rates are per species (births) or per animal (deaths) per in-game day.
+type PopulationConfig struct {
+	BirthRate     float64 `json:"BirthRate"`
+	DeathRate     float64 `json:"DeathRate"`
+	MaxBirthLevel int     `json:"MaxBirthLevel"`
+}
+
+// JuvenileRateBonus makes young animals a little easier to infect than adults.
+const JuvenileRateBonus = 1.15
+
+// SortedAnimalNames gives a stable iteration order so seeded runs stay reproducible.
+func SortedAnimalNames(state *GameState) []string {
+	names := make([]string, 0, len(state.Animals))
+	for name := range state.Animals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runPopulationDay rolls one day of births and deaths and returns a short report.
+func runPopulationDay(state *GameState) []string {
+	cfg := state.MapConfig.Population
+	if cfg.BirthRate <= 0 && cfg.DeathRate <= 0 {
+		return nil
+	}
+	maxBirth := MaxBirthLevel(cfg)
+
+	var report []string
+	alive := map[int]int{}
+	for _, a := range state.Animals {
+		if !a.RedHerring {
+			alive[a.Level]++
+		}
+	}
+
+	for _, name := range SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.Infected || a.RedHerring || a.Level >= state.MaxLevel || alive[a.Level] <= 1 {
+			continue
+		}
+		if state.RNG.Float64() < cfg.DeathRate {
+			delete(state.Animals, name)
+			state.Dead = append(state.Dead, a)
+			alive[a.Level]--
+			report = append(report, "⚰ "+name+" died")
+		}
+	}
+
+	for _, name := range SortedAnimalNames(state) {
+		parent := state.Animals[name]
+		if parent.RedHerring || parent.Juvenile || parent.Level > maxBirth {
+			continue
+		}
+		if state.RNG.Float64() < cfg.BirthRate {
+			young := newJuvenile(state, parent)
+			state.Animals[young.Name] = young
+			report = append(report, "🐣 "+young.Name+" was born")
+		}
+	}
+
+	return report
+}
+
+// MaxBirthLevel is the highest level that breeds, defaulting to 2.
+func MaxBirthLevel(cfg PopulationConfig) int {
+	if cfg.MaxBirthLevel <= 0 {
+		return 2
+	}
+	return cfg.MaxBirthLevel
+}
+
+func newJuvenile(state *GameState, parent *Animal) *Animal {
+	state.Births++
+
+	def := *parent.AnimalDef
+	def.Name = fmt.Sprintf("Young %s #%d", parent.Name, state.Births)
+	def.Species = parent.SpeciesName()
+	def.Contacts = append(ContactList{{Name: parent.Name, Weight: 1}}, parent.Contacts...)
+	def.ResistancePhases = nil
+
+	young := *parent
+	young.AnimalDef = &def
+	young.Juvenile = true
+	young.Infected = false
+	young.InfectionRate = parent.InfectionRate * JuvenileRateBonus
+	if young.InfectionRate > 1 {
+		young.InfectionRate = 1
+	}
+	if young.Intelligence > 1 {
+		young.Intelligence--
+	}
+	return &young
+}
+
+// SpeciesName is the name used for shared assets; juveniles use their parent's.
+func (a *Animal) SpeciesName() string {
+	if a.Species != "" {
+		return a.Species
+	}
+	return a.Name
+}
@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ===== PRACTICE MODE =====
+//
+// In practice mode every attempt can be undone, and the player can save named branch
+// points and return to them later. Practice runs are unranked: they are never saved to
+// the museum and their score is marked as practice.
+
+// Snapshot is a copy of everything an attempt or a wait can change. The journal is
+// deliberately left out so notes survive a revert. Snapshots are saved with the run, so
+// a resumed practice run keeps its undo and its branches.
+type Snapshot struct {
+	Name string
+	run  snapshotRun
+}
+
+// snapshotRun is what a Snapshot holds. Its fields are exported only so it can be
+// saved; nothing outside a Snapshot sees them.
+type snapshotRun struct {
+	Animals    map[string]*Animal `json:"animals"`
+	PlayerName string             `json:"host"`
+	CurrentDay int                `json:"day"`
+	Phase      DayPhase           `json:"phase"`
+	Virus      Virus              `json:"virus"`
+
+	Attempts  int `json:"attempts"`
+	SameLevel int `json:"same_level"`
+	NextLevel int `json:"next_level"`
+	// Elapsed is the game clock, so a revert also takes back the time penalty.
+	Elapsed time.Duration `json:"elapsed"`
+
+	BrokenPhases    map[string]int        `json:"broken_phases,omitempty"`
+	Wary            map[string]WaryStatus `json:"wary,omitempty"`
+	Cooldowns       map[string]int        `json:"cooldowns,omitempty"`
+	FailStreak      map[string]int        `json:"fail_streak,omitempty"`
+	AdviceDismissed map[string]bool       `json:"advice_dismissed,omitempty"`
+	Moods           map[string]MoodStatus `json:"moods,omitempty"`
+	Vaccinated      map[string]bool       `json:"vaccinated,omitempty"`
+	Latched         map[string]int        `json:"latched,omitempty"`
+	Immune          map[string]int        `json:"immune,omitempty"`
+	Priorities      map[string]bool       `json:"priorities,omitempty"`
+	MutationPoints  int                   `json:"mutation_points,omitempty"`
+	Upgrades        map[string]int        `json:"upgrades,omitempty"`
+	Effects         []TimedEffect         `json:"effects,omitempty"`
+	BurstArmed      bool                  `json:"burst_armed,omitempty"`
+	BurstUsed       bool                  `json:"burst_used,omitempty"`
+	LastInteraction map[string]string     `json:"last_interaction,omitempty"`
+	MercyUsed       bool                  `json:"mercy_used,omitempty"`
+	Births          int                   `json:"births,omitempty"`
+	DayReport       []string              `json:"day_report,omitempty"`
+	Lineage         []HostStep            `json:"lineage,omitempty"`
+	Transmissions   []Transmission        `json:"transmissions,omitempty"`
+	Dead            []*Animal             `json:"dead,omitempty"`
+	Replay          []ReplayStep          `json:"replay,omitempty"`
+	Coop            *PassAndPlay          `json:"pass_and_play,omitempty"`
+}
+
+// savedSnapshot is how a Snapshot is written to save.json.
+type savedSnapshot struct {
+	Name string      `json:"name"`
+	Run  snapshotRun `json:"run"`
+}
+
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(savedSnapshot{Name: s.Name, Run: s.run})
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	var saved savedSnapshot
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	s.Name, s.run = saved.Name, saved.Run
+	return nil
+}
+
+func takeSnapshot(state *GameState, name string) *Snapshot {
+	s := &Snapshot{Name: name, run: snapshotRun{
+		Animals:         make(map[string]*Animal, len(state.Animals)),
+		PlayerName:      state.PlayerName,
+		CurrentDay:      state.CurrentDay,
+		Phase:           state.Phase,
+		Virus:           Virus{Modes: slices.Clone(state.Virus.Modes), Strength: state.Virus.Strength},
+		Attempts:        state.Stats.Attempts,
+		SameLevel:       state.Stats.SameLevelInfections,
+		NextLevel:       state.Stats.NextLevelInfections,
+		Elapsed:         state.Stats.Clock.Elapsed(),
+		BrokenPhases:    maps.Clone(state.BrokenPhases),
+		Wary:            maps.Clone(state.Wary),
+		Cooldowns:       maps.Clone(state.Cooldowns),
+		FailStreak:      maps.Clone(state.FailStreak),
+		AdviceDismissed: maps.Clone(state.AdviceDismissed),
+		Moods:           maps.Clone(state.Moods),
+		Vaccinated:      maps.Clone(state.Vaccinated),
+		Latched:         maps.Clone(state.Latched),
+		Immune:          maps.Clone(state.Immune),
+		Priorities:      maps.Clone(state.Priorities),
+		MutationPoints:  state.MutationPoints,
+		Upgrades:        maps.Clone(state.Upgrades),
+		Effects:         slices.Clone(state.Effects),
+		BurstArmed:      state.BurstArmed,
+		BurstUsed:       state.BurstUsed,
+		LastInteraction: maps.Clone(state.LastInteraction),
+		MercyUsed:       state.MercyUsed,
+		Births:          state.Births,
+		DayReport:       slices.Clone(state.DayReport),
+		Lineage:         slices.Clone(state.Lineage),
+		Transmissions:   slices.Clone(state.Transmissions),
+		Dead:            slices.Clone(state.Dead),
+		Replay:          slices.Clone(state.Replay),
+		Coop:            cloneCoop(state.Coop),
+	}}
+	for name, a := range state.Animals {
+		c := *a
+		s.run.Animals[name] = &c
+	}
+	return s
+}
+
+// Restore puts the run back exactly as it was when s was taken. s stays usable, so a
+// branch can be returned to any number of times.
+func (s *Snapshot) Restore(state *GameState) {
+	r := &s.run
+	animals := make(map[string]*Animal, len(r.Animals))
+	for name, a := range r.Animals {
+		c := *a
+		animals[name] = &c
+	}
+
+	state.Animals = animals
+	state.PlayerName = r.PlayerName
+	state.CurrentDay = r.CurrentDay
+	state.Phase = r.Phase
+	state.Virus.Modes = slices.Clone(r.Virus.Modes)
+	state.Virus.Strength = r.Virus.Strength
+	state.Stats.Attempts = r.Attempts
+	state.Stats.SameLevelInfections = r.SameLevel
+	state.Stats.NextLevelInfections = r.NextLevel
+	state.Stats.Clock.SetElapsed(r.Elapsed)
+	state.BrokenPhases = cloneMap(r.BrokenPhases)
+	state.Wary = cloneMap(r.Wary)
+	state.Cooldowns = cloneMap(r.Cooldowns)
+	state.FailStreak = cloneMap(r.FailStreak)
+	state.AdviceDismissed = cloneMap(r.AdviceDismissed)
+	state.Moods = cloneMap(r.Moods)
+	state.Vaccinated = cloneMap(r.Vaccinated)
+	state.Latched = cloneMap(r.Latched)
+	state.Immune = cloneMap(r.Immune)
+	state.Priorities = cloneMap(r.Priorities)
+	state.MutationPoints = r.MutationPoints
+	state.Upgrades = cloneMap(r.Upgrades)
+	state.Effects = slices.Clone(r.Effects)
+	state.BurstArmed = r.BurstArmed
+	state.BurstUsed = r.BurstUsed
+	state.LastInteraction = cloneMap(r.LastInteraction)
+	state.MercyUsed = r.MercyUsed
+	state.Births = r.Births
+	state.DayReport = slices.Clone(r.DayReport)
+	state.Lineage = slices.Clone(r.Lineage)
+	state.Transmissions = slices.Clone(r.Transmissions)
+	state.Replay = slices.Clone(r.Replay)
+	state.Dead = slices.Clone(r.Dead)
+	state.Coop = cloneCoop(r.Coop)
+}
+
+// cloneMap copies m, giving an empty map for nil: a snapshot read back from save.json
+// has nil for the maps that were empty when it was taken, and the run writes to them.
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return map[K]V{}
+	}
+	return maps.Clone(m)
+}
+
+// cloneCoop copies a pass-and-play turn order and its notes, or returns nil.
+func cloneCoop(c *PassAndPlay) *PassAndPlay {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.Notes = make([]map[string]string, len(c.Notes))
+	for i, n := range c.Notes {
+		out.Notes[i] = maps.Clone(n)
+	}
+	return &out
+}
+
+// RememberUndo saves the state before an attempt or wait, in practice mode only.
+func RememberUndo(state *GameState) {
+	if state.Rules.Practice {
+		state.Undo = takeSnapshot(state, "undo")
+	}
+}
+
+func SaveBranch(state *GameState, name string) {
+	for i, b := range state.Branches {
+		if b.Name == name {
+			state.Branches[i] = takeSnapshot(state, name)
+			return
+		}
+	}
+	state.Branches = append(state.Branches, takeSnapshot(state, name))
+}
+
+func RevertToBranch(state *GameState, name string) bool {
+	for _, b := range state.Branches {
+		if b.Name == name {
+			b.Restore(state)
+			state.Undo = nil
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"math/rand"
+)
+
+// ===== REPLAYS =====
+
+// ReplayStep is one decision and what came of it.
+type ReplayStep struct {
+	Action string  `json:"action"`
+	Result string  `json:"result"`
+	Day    int     `json:"day"`
+	Phase  string  `json:"phase"`
+	Host   string  `json:"host"`
+	Score  int     `json:"score"`
+	Draws  []int64 `json:"draws,omitempty"`
+}
+
+// drawRecorder is the source of a recorded run's generator: it keeps every draw until
+// recordStep files them with the step that caused them, and counts every draw since the
+// run was seeded so a saved run can pick up where it left off.
+type drawRecorder struct {
+	src   rand.Source
+	draws []int64
+	count int64
+}
+
+func (d *drawRecorder) Int63() int64 {
+	v := d.src.Int63()
+	d.draws = append(d.draws, v)
+	d.count++
+	return v
+}
+
+func (d *drawRecorder) Seed(seed int64) { d.src.Seed(seed) }
+
+// RecordDraws gives state a generator on src whose draws are recorded.
+func RecordDraws(state *GameState, src rand.Source) {
+	state.draws = &drawRecorder{src: src}
+	state.RNG = rand.New(state.draws)
+}
+
+// DrawsUsed is how many draws the run has made since it was seeded.
+func DrawsUsed(state *GameState) int64 {
+	if state.draws == nil {
+		return 0
+	}
+	return state.draws.count
+}
+
+// ResumeDraws reseeds state's generator from its seed and skips the first n draws, so a
+// run saved after n draws rolls on exactly as it would have without the interruption.
+func ResumeDraws(state *GameState, n int64) {
+	src := rand.NewSource(state.Seed)
+	for range n {
+		src.Int63()
+	}
+	RecordDraws(state, src)
+	state.draws.count = n
+}
+
+// recordStep files a decision the player just made, with the draws it used. Bot runs
+// and replays are not recorded.
+func recordStep(state *GameState, action, result string) {
+	if state.draws == nil {
+		return
+	}
+	draws := state.draws.draws
+	state.draws.draws = nil
+	if state.BotPlayed {
+		return
+	}
+	state.Replay = append(state.Replay, ReplayStep{
+		Action: action,
+		Result: result,
+		Day:    state.CurrentDay,
+		Phase:  state.Phase.String(),
+		Host:   state.PlayerName,
+		Score:  CalculateScore(state),
+		Draws:  draws,
+	})
+}
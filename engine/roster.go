@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===== ROSTER =====
+//
+// A map's animals are parsed once into a roster and every run on the map copies its
+// animals from it. The copies share the roster's AnimalDefs, so a run, a practice
+// branch or a second session on a server can only change its own Animals, never the
+// map data the next run starts from. The roster is parsed again when the file changes.
+
+// Roster is the animals of one map file as the file has them.
+type Roster struct {
+	modTime  time.Time
+	Animals  map[string]Animal
+	MaxLevel int
+}
+
+// rosters caches one roster per map path. Simulations load maps from other goroutines.
+var (
+	rostersMu sync.Mutex
+	rosters   = map[string]*Roster{}
+)
+
+// LoadRoster returns the roster of the map at path, parsing it if it is new or changed
+// on disk. A missing or invalid map gives an empty roster.
+func LoadRoster(path string) *Roster {
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	rostersMu.Lock()
+	defer rostersMu.Unlock()
+	if r, ok := rosters[path]; ok && r.modTime.Equal(modTime) {
+		return r
+	}
+	r := parseRoster(path)
+	r.modTime = modTime
+	rosters[path] = r
+	return r
+}
+
+func parseRoster(path string) *Roster {
+	r := &Roster{Animals: map[string]Animal{}}
+	data, _ := os.ReadFile(path)
+
+	var raw map[string]json.RawMessage
+	json.Unmarshal(data, &raw)
+
+	for key, msg := range raw {
+		if !strings.HasPrefix(key, "Level") {
+			continue
+		}
+		var arr []*Animal
+		json.Unmarshal(msg, &arr)
+		for _, a := range arr {
+			if a == nil || a.AnimalDef == nil {
+				continue
+			}
+			r.Animals[a.Name] = *a
+			if a.Level > r.MaxLevel {
+				r.MaxLevel = a.Level
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ===== RULES =====
+
+// RulesConfig holds the optional rules chosen before a run. Anything that makes a run
+// easier must be disclosed through ScoreMultiplier.
+type RulesConfig struct {
+	// MercyRule makes the first infection roll of the run succeed automatically.
+	MercyRule bool `json:"mercy_rule"`
+	// OutbreakPercent switches to outbreak victory: win by infecting this share of all
+	// non-red-herring animals instead of reaching the apex. Zero means apex victory.
+	OutbreakPercent int `json:"outbreak_percent,omitempty"`
+	// WildGenetics perturbs each animal's rate and intelligence at the start of the run.
+	WildGenetics bool `json:"wild_genetics,omitempty"`
+	// AttemptBudget caps the infection attempts for the whole run; running out before
+	// winning ends the game. Zero means unlimited.
+	AttemptBudget int `json:"attempt_budget,omitempty"`
+	// RetryCooldown stops a target that resisted from being retried until the next day.
+	RetryCooldown bool `json:"retry_cooldown,omitempty"`
+	// ContactWeighted scales each chance by the target's strongest contact weight to an
+	// infected animal.
+	ContactWeighted bool `json:"contact_weighted,omitempty"`
+	// Practice allows undo and branch points; the run is unranked.
+	Practice bool `json:"practice,omitempty"`
+	// TwoStage splits taking a host into a latch and a takeover on a later day.
+	TwoStage bool `json:"two_stage,omitempty"`
+	// PassiveSpread lets the virus spread along contacts each morning, and lets the
+	// player mark priority targets for it instead of attempting.
+	PassiveSpread bool `json:"passive_spread,omitempty"`
+	// Mutations lets infections earn points to spend on virus upgrades; see mutations.go.
+	Mutations bool `json:"mutations,omitempty"`
+	// Immunity lets infected animals other than the host recover and turn immune; see
+	// immunity.go.
+	Immunity bool `json:"immunity,omitempty"`
+	// Curses makes every attempt on a red herring curse the virus; see curses.go.
+	Curses bool `json:"curses,omitempty"`
+	// Players is the number of players taking turns on one device, each with their own
+	// notes; zero or one is a solo run. See coop.go.
+	Players int `json:"players,omitempty"`
+	// Weekly is the weekly mutation played, if any; see weekly.go.
+	Weekly WeeklyMutation `json:"weekly,omitzero"`
+}
+
+const MercyScoreMultiplier = 0.9
+
+// RuleToggle is one optional rule and whether the run has it on.
+type RuleToggle struct {
+	Name        string
+	On          bool
+	Description string
+}
+
+// Toggles lists every optional rule for the rules reference; keep it in step with the
+// fields of RulesConfig.
+func (r RulesConfig) Toggles() []RuleToggle {
+	return []RuleToggle{
+		{"Mercy rule", r.MercyRule, fmt.Sprintf("the first infection roll always succeeds; score ×%.2f", MercyScoreMultiplier)},
+		{"Outbreak victory", r.OutbreakPercent > 0, "win by infecting a share of the wildlife instead of reaching the apex"},
+		{"Wild genetics", r.WildGenetics, "each animal's rate and intelligence are perturbed at the start of the run"},
+		{"Attempt budget", r.AttemptBudget > 0, "the run is lost when the attempts run out"},
+		{"Retry cooldown", r.RetryCooldown, fmt.Sprintf("a target that resists cannot be retried for %d day(s)", CooldownDays)},
+		{"Contact graph", r.ContactWeighted, "chances scale with the target's strongest contact to an infected animal"},
+		{"Practice", r.Practice, "undo and branch points; the run is unranked"},
+		{"Two-stage infection", r.TwoStage, "latch onto a host first, then take it over on a later day"},
+		{"Passive spread", r.PassiveSpread, "the virus spreads along contacts each morning; priority targets steer it"},
+		{"Mutations", r.Mutations, "infections earn points to spend on virus upgrades between days"},
+		{"Immune response", r.Immunity, "infected animals left behind may recover each morning and are then immune"},
+		{"Curses", r.Curses, "an attempt on a red herring curses the virus: blindness or weakness for a while"},
+		{"Pass and play", r.Players > 1, "players take turns on one device; each sees only what their own attempts revealed"},
+		{"Weekly mutation", r.Weekly.Active(), "this week's extra chance modifier; the run ranks on the weekly board"},
+	}
+}
+
+func (r RulesConfig) ScoreMultiplier() float64 {
+	m := 1.0
+	if r.MercyRule {
+		m *= MercyScoreMultiplier
+	}
+	return m
+}
+
+// Disclosure lists the active score-affecting rules, e.g. "mercy rule ×0.90".
+func (r RulesConfig) Disclosure() string {
+	var parts []string
+	if r.MercyRule {
+		parts = append(parts, fmt.Sprintf("mercy rule ×%.2f", MercyScoreMultiplier))
+	}
+	if r.Weekly.Active() {
+		parts = append(parts, "weekly "+r.Weekly.Label())
+	}
+	if r.Practice {
+		parts = append(parts, "practice, unranked")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// OutbreakOptions are the outbreak targets offered at run start.
+var OutbreakOptions = []int{50, 75}
+
+func (r RulesConfig) VictoryDescription() string {
+	if r.OutbreakPercent > 0 {
+		return fmt.Sprintf("Outbreak: infect %d%% of wildlife", r.OutbreakPercent)
+	}
+	return "Reach the apex"
+}
+
+// BudgetOptions are the attempt budgets offered at run start.
+var BudgetOptions = []int{15, 25}
+
+func (r RulesConfig) BudgetDescription() string {
+	if r.AttemptBudget > 0 {
+		return fmt.Sprintf("%d attempts", r.AttemptBudget)
+	}
+	return "Unlimited attempts"
+}
+
+// AttemptsLeft is the remaining attempt budget, or -1 when the run has no budget.
+func AttemptsLeft(state *GameState) int {
+	if state.Rules.AttemptBudget <= 0 {
+		return -1
+	}
+	if left := state.Rules.AttemptBudget - state.Stats.Attempts; left > 0 {
+		return left
+	}
+	return 0
+}
+
+// MercyApplies reports whether the next roll should be waived by the mercy rule.
+func MercyApplies(state *GameState) bool {
+	return state.Rules.MercyRule && !state.MercyUsed
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// ===== SCORE =====
+
+const (
+	ScoreBase             = 1000
+	ScoreNextLevelBonus   = 200
+	ScoreSameLevelPenalty = 100
+	ScoreAttemptPenalty   = 10
+)
+
+func CalculateScore(state *GameState) int {
+	return scoreWith(state, state.Stats.NextLevelInfections, state.Stats.SameLevelInfections, state.Stats.Attempts)
+}
+
+// scoreWith is the score state's run would have with the given tallies at the current
+// time, with the ending bonus of an apex host.
+func scoreWith(state *GameState, nextLevel, sameLevel, attempts int) int {
+	penalty := int(ScoringOf(state).Penalty(state.Stats.Clock.Elapsed().Seconds()))
+	score := ScoreBase + (nextLevel * ScoreNextLevelBonus) - (sameLevel * ScoreSameLevelPenalty) - (attempts * ScoreAttemptPenalty) - penalty
+	score += EndingBonus(state, state.Animals[state.PlayerName])
+	if score < 0 {
+		score = 0
+	}
+	return int(float64(score) * state.Rules.ScoreMultiplier())
+}
+
+// ScoreProjection is what the score would be if the run were won from here on the
+// shortest path: every remaining infection a first-try success, a level up where one is
+// still to be had, and no more time spent.
+type ScoreProjection struct {
+	Now int
+	// IfWon is the projected final score; Infections is how many infections it assumes.
+	IfWon      int
+	Infections int
+	// SameLevelCost is what one extra same-level infection now would take off IfWon.
+	SameLevelCost int
+}
+
+func projectScore(state *GameState) ScoreProjection {
+	s := &state.Stats
+	host := state.Animals[state.PlayerName]
+	levelsLeft := 0
+	if host != nil {
+		levelsLeft = max(0, state.MaxLevel-host.Level)
+	}
+	ups, same := levelsLeft, 0
+	if state.Rules.OutbreakPercent > 0 {
+		total, infected := 0, 0
+		for _, a := range state.Animals {
+			if !a.RedHerring {
+				total++
+				if a.Infected {
+					infected++
+				}
+			}
+		}
+		need := max(0, (total*state.Rules.OutbreakPercent+99)/100-infected)
+		ups = min(need, levelsLeft)
+		same = need - ups
+	}
+
+	p := ScoreProjection{Now: CalculateScore(state), Infections: ups + same}
+	p.IfWon = scoreWith(state, s.NextLevelInfections+ups, s.SameLevelInfections+same, s.Attempts+ups+same)
+	p.SameLevelCost = p.IfWon - scoreWith(state, s.NextLevelInfections+ups, s.SameLevelInfections+same+1, s.Attempts+ups+same+1)
+	return p
+}
+
+// ProjectionLine is the HUD projection text, e.g. "If you win now: 1540 (2 more
+// infections; a same-level detour costs 110)".
+func ProjectionLine(state *GameState) string {
+	p := projectScore(state)
+	return fmt.Sprintf("If you win now: %d (%d more infection(s); a same-level detour costs %d)", p.IfWon, p.Infections, p.SameLevelCost)
+}
+
+// ScoreLine is the HUD score text, disclosing any rule that scales it.
+func ScoreLine(state *GameState) string {
+	line := fmt.Sprintf("Score: %d", CalculateScore(state))
+	if d := state.Rules.Disclosure(); d != "" {
+		line += " (" + d + ")"
+	}
+	return line
+}
@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// ===== MAP SCRIPTS =====
+//
+// A map can name a Starlark script under Config.Script (relative to the map file). The
+// script may define any of these hooks:
+//
+//	def onDayStart(day): ...
+//	def onInfectionSuccess(target, host): ...
+//
+// and call the functions of the `game` module:
+//
+//	game.day(), game.host()         current day and host name
+//	game.animal(name)               a read-only struct of the animal's stats, or None
+//	game.set_rate(name, rate)       change an animal's infection rate (0 to 1)
+//	game.set_strength(x)            change the virus strength
+//	game.add_mode(mode)             give the virus a transmission mode
+//	game.report(msg)                add a line to today's report
+//	game.random()                   a seeded random number in [0, 1)
+//
+// Scripts have no file, network or clock access, and each hook call is cut off after
+// scriptMaxSteps steps or scriptTimeout, whichever comes first.
+
+const (
+	scriptMaxSteps = 100000
+	scriptTimeout  = 200 * time.Millisecond
+)
+
+// MapScript is a loaded map script and its hooks.
+type MapScript struct {
+	Path    string
+	globals starlark.StringDict
+	// failed stops calling a script after its first runtime error.
+	failed bool
+}
+
+// LoadMapScript runs the script's top level once; state is the game the hooks will act on.
+func LoadMapScript(state *GameState, path string) (*MapScript, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	thread := newScriptThread(path)
+	predeclared := starlark.StringDict{"game": scriptModule(state)}
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, src, predeclared)
+	if err != nil {
+		return nil, err
+	}
+	return &MapScript{Path: path, globals: globals}, nil
+}
+
+// ScriptPath resolves Config.Script relative to the map file.
+func ScriptPath(mapPath, script string) string {
+	if script == "" || filepath.IsAbs(script) {
+		return script
+	}
+	return filepath.Join(filepath.Dir(mapPath), script)
+}
+
+func newScriptThread(name string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name:  name,
+		Print: func(_ *starlark.Thread, msg string) { slog.Info("script print", "script", name, "msg", msg) },
+	}
+	thread.SetMaxExecutionSteps(scriptMaxSteps)
+	return thread
+}
+
+// runHook calls the named hook if the map script defines it. Errors are reported once and
+// disable the script for the rest of the run.
+func runHook(state *GameState, hook string, args ...starlark.Value) {
+	s := state.script
+	if s == nil || s.failed {
+		return
+	}
+	fn, ok := s.globals[hook].(starlark.Callable)
+	if !ok {
+		return
+	}
+
+	thread := newScriptThread(s.Path)
+	timer := time.AfterFunc(scriptTimeout, func() { thread.Cancel("time limit exceeded") })
+	defer timer.Stop()
+
+	if _, err := starlark.Call(thread, fn, args, nil); err != nil {
+		s.failed = true
+		slog.Error("script hook failed", "hook", hook, "err", err)
+		state.DayReport = append(state.DayReport, "⚠ Map script stopped: "+hook+" failed")
+	}
+}
+
+func scriptModule(state *GameState) *starlarkstruct.Module {
+	builtin := func(name string, fn func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)) *starlark.Builtin {
+		return starlark.NewBuiltin(name, func(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return fn(args, kwargs)
+		})
+	}
+
+	return &starlarkstruct.Module{Name: "game", Members: starlark.StringDict{
+		"day": builtin("day", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return starlark.MakeInt(state.CurrentDay), starlark.UnpackArgs("day", args, kwargs)
+		}),
+		"host": builtin("host", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return starlark.String(state.PlayerName), starlark.UnpackArgs("host", args, kwargs)
+		}),
+		"animal": builtin("animal", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			if err := starlark.UnpackArgs("animal", args, kwargs, "name", &name); err != nil {
+				return nil, err
+			}
+			a := state.Animals[name]
+			if a == nil {
+				return starlark.None, nil
+			}
+			return starlarkstruct.FromStringDict(starlark.String("animal"), starlark.StringDict{
+				"name":           starlark.String(a.Name),
+				"species":        starlark.String(a.SpeciesName()),
+				"level":          starlark.MakeInt(a.Level),
+				"mobility":       starlark.String(a.Mobility),
+				"intelligence":   starlark.MakeInt(a.Intelligence),
+				"location":       starlark.String(a.Location),
+				"infected":       starlark.Bool(a.Infected),
+				"infection_rate": starlark.Float(a.InfectionRate),
+				"red_herring":    starlark.Bool(a.RedHerring),
+			}), nil
+		}),
+		"set_rate": builtin("set_rate", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			var rate float64
+			if err := starlark.UnpackArgs("set_rate", args, kwargs, "name", &name, "rate", &rate); err != nil {
+				return nil, err
+			}
+			a := state.Animals[name]
+			if a == nil {
+				return nil, fmt.Errorf("set_rate: no animal named %q", name)
+			}
+			if rate < 0 || rate > 1 {
+				return nil, fmt.Errorf("set_rate: rate %g is not between 0 and 1", rate)
+			}
+			a.InfectionRate = rate
+			return starlark.None, nil
+		}),
+		"set_strength": builtin("set_strength", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var x float64
+			if err := starlark.UnpackArgs("set_strength", args, kwargs, "x", &x); err != nil {
+				return nil, err
+			}
+			if x < 0 {
+				return nil, fmt.Errorf("set_strength: strength %g is negative", x)
+			}
+			state.Virus.Strength = x
+			return starlark.None, nil
+		}),
+		"add_mode": builtin("add_mode", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var mode string
+			if err := starlark.UnpackArgs("add_mode", args, kwargs, "mode", &mode); err != nil {
+				return nil, err
+			}
+			if !state.Virus.HasMode(mode) {
+				state.Virus.Modes = append(state.Virus.Modes, mode)
+			}
+			return starlark.None, nil
+		}),
+		"report": builtin("report", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var msg string
+			if err := starlark.UnpackArgs("report", args, kwargs, "msg", &msg); err != nil {
+				return nil, err
+			}
+			state.DayReport = append(state.DayReport, msg)
+			return starlark.None, nil
+		}),
+		"random": builtin("random", func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			return starlark.Float(state.RNG.Float64()), starlark.UnpackArgs("random", args, kwargs)
+		}),
+	}}
+}
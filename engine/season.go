@@ -0,0 +1,81 @@
+package engine
+
+// ===== SEASONS =====
+
+const DaysPerSeason = 10
+
+type Season int
+
+const (
+	SeasonSpring Season = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+)
+
+const (
+	SeasonHibernating = "Hibernating"
+	SeasonMigrated    = "Migrated"
+)
+
+// SeasonalBehavior is the per-animal override for one season, keyed by season name in the map data.
+type SeasonalBehavior struct {
+	State          string  `json:"State"`
+	RateMultiplier float64 `json:"RateMultiplier"`
+}
+
+func SeasonForDay(day int) Season {
+	return Season((day / DaysPerSeason) % 4)
+}
+
+func DaysUntilNextSeason(day int) int {
+	return DaysPerSeason - day%DaysPerSeason
+}
+
+func (s Season) String() string {
+	switch s {
+	case SeasonSpring:
+		return "Spring"
+	case SeasonSummer:
+		return "Summer"
+	case SeasonAutumn:
+		return "Autumn"
+	default:
+		return "Winter"
+	}
+}
+
+func (s Season) Icon() string {
+	switch s {
+	case SeasonSpring:
+		return "🌱"
+	case SeasonSummer:
+		return "🌻"
+	case SeasonAutumn:
+		return "🍂"
+	default:
+		return "❄️"
+	}
+}
+
+func (a *Animal) SeasonalBehavior(s Season) SeasonalBehavior {
+	return a.Seasons[s.String()]
+}
+
+func (a *Animal) IsHibernating(s Season) bool {
+	return a.SeasonalBehavior(s).State == SeasonHibernating
+}
+
+// IsPresent reports whether the animal is in the park at all this season.
+func (a *Animal) IsPresent(s Season) bool {
+	return a.SeasonalBehavior(s).State != SeasonMigrated
+}
+
+// SeasonalRate is the animal's infection rate adjusted for the season.
+func (a *Animal) SeasonalRate(s Season) float64 {
+	m := a.SeasonalBehavior(s).RateMultiplier
+	if m == 0 {
+		m = 1
+	}
+	return a.InfectionRate * m
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ===== FILE NAME SLUGS =====
+//
+// Animal names make poor file names: spaces, apostrophes and accents are spelled
+// differently by different filesystems (macOS stores "é" decomposed, Windows folds
+// case, archives mangle both). Every file named after something in the game is
+// therefore named by its slug, lowercase ASCII letters and digits joined by dashes:
+// "Short-Horned Lizard" is png/short-horned-lizard.png.
+//
+// Files still under their old names keep working: lookups fall back to the unslugged
+// name when no slugged file exists, and "rawr assets migrate" renames them.
+
+// Slug turns a display name into a portable file name stem. Accents are dropped,
+// apostrophes vanish and any other run of punctuation or spaces becomes one dash, so
+// "Bewick's Wren" is "bewicks-wren". Letters of other scripts are kept as they are.
+func Slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFKD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "unnamed"
+	}
+	return norm.NFC.String(b.String())
+}
+
+// assetPath is the file for name in dir: dir/<slug><ext>, or the file under the plain
+// name when only that one exists.
+func assetPath(dir, name, ext string) string {
+	p := filepath.Join(dir, Slug(name)+ext)
+	if !FileExists(p) {
+		if legacy := filepath.Join(dir, name+ext); FileExists(legacy) {
+			return legacy
+		}
+	}
+	return p
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ===== PASSIVE SPREAD =====
+//
+// With the passive spread rule, the virus also moves on its own: each morning every
+// exposed animal (one with an infected contact) may catch it at SpreadFactor of its
+// base chance. Spread fills out the outbreak at and below the host's level; it never
+// breaks defenses or changes the host, so evolving still takes an attempt.
+//
+// Instead of attempting an infection, the player may spend a day steering the spread:
+// up to PriorityLimit exposed animals are marked as priority targets, and their spread
+// chance next morning is multiplied by PriorityBonus. Once a priority is set, no manual
+// attempt can be made until the next day.
+
+const (
+	SpreadFactor  = 0.25
+	PriorityBonus = 2.0
+	PriorityLimit = 3
+)
+
+var ErrPriorityLimit = fmt.Errorf("at most %d priority targets can be marked a day", PriorityLimit)
+
+// SpreadSource is the infected contact a could catch the virus from, the one with the
+// strongest relationship, or nil if a is not exposed to passive spread.
+func SpreadSource(state *GameState, a *Animal) *Animal {
+	host := state.Animals[state.PlayerName]
+	season := SeasonForDay(state.CurrentDay)
+	if host == nil || a.Infected || a.RedHerring || state.Vaccinated[a.Name] || IsImmune(state, a) || a.Level > host.Level ||
+		!a.IsPresent(season) || a.IsHibernating(season) {
+		return nil
+	}
+	if phase, _ := CurrentResistancePhase(state, a); phase != nil {
+		return nil
+	}
+	var from *Animal
+	best := 0.0
+	for _, name := range SortedAnimalNames(state) {
+		other := state.Animals[name]
+		if other == a || !other.Infected {
+			continue
+		}
+		if w, ok := EdgeWeight(a, other); ok && (from == nil || w > best) {
+			from, best = other, w
+		}
+	}
+	return from
+}
+
+// ExposedAnimals lists the animals passive spread could reach next, sorted by name.
+func ExposedAnimals(state *GameState) []*Animal {
+	var out []*Animal
+	for _, name := range SortedAnimalNames(state) {
+		if a := state.Animals[name]; SpreadSource(state, a) != nil {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// SpreadChance is the chance a catches the virus overnight, with any priority bonus.
+func SpreadChance(state *GameState, a *Animal) float64 {
+	chance := BaseChance(state, a) * SpreadFactor
+	if state.Priorities[a.Name] {
+		chance *= PriorityBonus
+	}
+	return math.Min(1, chance)
+}
+
+// TogglePriority marks a as a priority target for tonight's spread, or unmarks it.
+func TogglePriority(state *GameState, a *Animal) error {
+	if !state.Rules.PassiveSpread {
+		return fmt.Errorf("priority targets need the passive spread rule")
+	}
+	if state.Priorities[a.Name] {
+		delete(state.Priorities, a.Name)
+		recordStep(state, "priority "+a.Name, "unmarked")
+		return nil
+	}
+	if SpreadSource(state, a) == nil {
+		return fmt.Errorf("%s has no infected contact to catch the virus from", a.Name)
+	}
+	if len(state.Priorities) >= PriorityLimit {
+		return ErrPriorityLimit
+	}
+	state.Priorities[a.Name] = true
+	recordStep(state, "priority "+a.Name, "marked")
+	return nil
+}
+
+// steeringSpread reports whether the player has spent today on priority targets, which
+// rules out manual attempts until tomorrow.
+func steeringSpread(state *GameState) bool {
+	return len(state.Priorities) > 0
+}
+
+// passiveSpread rolls the night's spread; it runs at the start of each day, and the
+// priorities it used are cleared. The exposure is decided before anything is infected,
+// so the virus moves at most one contact a night.
+func passiveSpread(state *GameState) []string {
+	if !state.Rules.PassiveSpread || state.PlayerName == "" {
+		return nil
+	}
+	defer clear(state.Priorities)
+
+	type catch struct{ to, from *Animal }
+	var caught []catch
+	for _, a := range ExposedAnimals(state) {
+		if state.RNG.Float64() < SpreadChance(state, a) {
+			caught = append(caught, catch{a, SpreadSource(state, a)})
+		}
+	}
+
+	var report []string
+	when := fmt.Sprintf("Day %d %s", state.CurrentDay, state.Phase)
+	for _, c := range caught {
+		c.to.Infected = true
+		delete(state.Moods, c.to.Name)
+		delete(state.Latched, c.to.Name)
+		state.LastInteraction[c.to.Name] = when + ": caught it from " + c.from.Name
+		state.Transmissions = append(state.Transmissions, Transmission{From: c.from.Name, To: c.to.Name, Day: state.CurrentDay})
+		report = append(report, "🦠 "+c.to.Name+" caught it from "+c.from.Name)
+	}
+	return report
+}
@@ -0,0 +1,356 @@
+// Package engine is the game itself: the animals and the virus, one run's GameState,
+// infection resolution, the day cycle and scoring. It draws nothing; the Fyne window
+// and the terminal front-ends (cli, batch, simulate, the dashboard) all play through it.
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// ===== GAME DATA =====
+
+// AnimalDef is an animal as its map defines it. Every run on a map shares the same
+// definitions and none of them changes one; see roster.go.
+type AnimalDef struct {
+	Name           string      `json:"Name"`
+	Level          int         `json:"Level"`
+	Mobility       string      `json:"Mobility"`
+	Contacts       ContactList `json:"Contacts"`
+	ActivityPeriod string      `json:"ActivityPeriod"`
+	RedHerring     bool        `json:"RedHerring"`
+
+	Seasons          map[string]SeasonalBehavior `json:"Seasons"`
+	ResistancePhases []ResistancePhase           `json:"ResistancePhases"`
+	// Ending is what winning on this animal means, if it is an apex; see apex.go.
+	Ending *ApexEnding `json:"Ending,omitempty"`
+
+	Genetics *GeneticsRange `json:"Genetics"`
+
+	Species string       `json:"Species"`
+	Sounds  AnimalSounds `json:"Sounds"`
+}
+
+// AnimalSounds are optional per-animal cues from the map data; empty fields fall back
+// to the default sound effects.
+type AnimalSounds struct {
+	Evolve string `json:"Evolve"`
+	Resist string `json:"Resist"`
+}
+
+// Animal is one animal in a run: its shared definition and the state the run changes,
+// which starts out as the map has it.
+type Animal struct {
+	*AnimalDef
+
+	Intelligence  int     `json:"Intelligence"`
+	Infected      bool    `json:"Infected"`
+	InfectionRate float64 `json:"InfectionRate"`
+	Location      string  `json:"Location"`
+	Juvenile      bool    `json:"-"`
+}
+
+const DefaultImageDir = "png"
+
+// ImageDir holds the animal portraits; a custom map can point it at its own assets.
+var ImageDir = DefaultImageDir
+
+func (a *Animal) GetImagePath() string {
+	return assetPath(ImageDir, a.SpeciesName(), ".png")
+}
+
+type Virus struct {
+	Modes    []string
+	Strength float64
+}
+
+type RedHerringInfo struct {
+	FunFact string `json:"FunFact"`
+	Reason  string `json:"Reason"`
+}
+
+type Stats struct {
+	Attempts            int
+	SameLevelInfections int
+	NextLevelInfections int
+	StartTime           time.Time
+	Clock               GameClock
+}
+
+// GameState is owned by the goroutine that drives the run: the UI goroutine in the
+// window, or the caller in batch and simulate. It has no lock of its own, so any other
+// goroutine (timers, animations, file watchers, observers) must not touch it directly
+// and instead hands its work over with fyne.Do, or works on values copied out first.
+type GameState struct {
+	Animals    map[string]*Animal
+	PlayerName string
+	MaxLevel   int
+	CurrentDay int
+	Phase      DayPhase
+	Virus      *Virus
+	Stats      Stats
+	RedFacts   map[string]RedHerringInfo
+	// Conservation is the real-world status of each species; see conservation.go.
+	Conservation map[string]ConservationInfo
+	score        int
+
+	BrokenPhases map[string]int
+	Wary         map[string]WaryStatus
+	Cooldowns    map[string]int
+	// FailStreak counts failures in a row per target; AdviceDismissed marks streaks the
+	// player no longer wants advice on.
+	FailStreak      map[string]int
+	AdviceDismissed map[string]bool
+	Moods           map[string]MoodStatus
+	// Vaccinated animals cannot be infected; only reverse mode vaccinates.
+	Vaccinated map[string]bool
+	// Immune maps each animal that recovered to the day it did; see immunity.go.
+	Immune map[string]int
+	// Effects are temporary changes to the virus; BurstArmed and BurstUsed track the
+	// once-per-run mutation burst. See burst.go.
+	Effects    []TimedEffect
+	BurstArmed bool
+	BurstUsed  bool
+	// Latched maps each animal the virus has latched onto to the day it latched, under
+	// the two-stage rule; see latch.go.
+	Latched map[string]int
+	// Priorities are the animals marked for tonight's passive spread; see spread.go.
+	Priorities map[string]bool
+	// MutationPoints are unspent, and Upgrades counts each upgrade bought; see
+	// mutations.go.
+	MutationPoints int
+	Upgrades       map[string]int
+	// BotPlayed is set when the simulation bot plays the run, in simulations and in
+	// reverse mode's outbreak.
+	BotPlayed bool
+
+	LastInteraction map[string]string
+
+	Rules     RulesConfig
+	MercyUsed bool
+	Genetics  map[string]GeneticShift
+
+	MapPath         string
+	MapConfig       MapConfig
+	Modifiers       []*Modifier
+	script          *MapScript
+	NGPlus          int
+	CarriedMutation string
+
+	Births    int
+	DayReport []string
+
+	// Seed is the run's seed; every roll of the run draws from RNG, which it seeds.
+	Seed    int64
+	RNG     *rand.Rand
+	Starter string
+	// Lineage is every host of the run in order, patient zero first; see lineage.go.
+	Lineage       []HostStep
+	Transmissions []Transmission
+	// Dead are the animals removed by population deaths, in order.
+	Dead []*Animal
+	// RunID identifies the finished run in the run history; 0 until it is recorded.
+	RunID   int64
+	Journal []JournalNote
+
+	Undo     *Snapshot
+	Branches []*Snapshot
+
+	// Coop is the pass-and-play turn order and each player's notes; see coop.go.
+	Coop *PassAndPlay
+
+	// Replay is every decision of the run so far, and draws records the rolls each one
+	// made; see replay.go.
+	Replay []ReplayStep
+	draws  *drawRecorder
+}
+
+// MapConfig holds map-wide settings from the "Config" key of a map file.
+type MapConfig struct {
+	// Name is the ecosystem's name on the selection screen; see ecosystems.go.
+	Name string `json:"Name"`
+	// Background is the image behind every screen, relative to the map.
+	Background string `json:"Background"`
+	// Assets is a folder of portraits and red herring facts, relative to the map, used
+	// like the folder chosen for a custom map.
+	Assets string `json:"Assets"`
+
+	Population PopulationConfig `json:"Population"`
+	Genetics   GeneticsRange    `json:"Genetics"`
+	// Modifiers are chance modifier expressions; see modifiers.go.
+	Modifiers []string `json:"Modifiers"`
+	// Script is a Starlark file of event hooks, relative to the map; see scripting.go.
+	Script string `json:"Script"`
+	// StarterLevel is the level patient zero is chosen from; zero means level 1.
+	StarterLevel int `json:"StarterLevel"`
+	// Medals are the winning scores for bronze, silver and gold; see celebrate.go.
+	Medals MedalConfig `json:"Medals"`
+	// Crits are the chances of critical outcomes; see crits.go.
+	Crits CritConfig `json:"Crits"`
+	// Scoring is the time penalty curve; see timepenalty.go.
+	Scoring ScoringConfig `json:"Scoring"`
+	// Font is a TTF file, relative to the map, for the map's facts and reasons.
+	Font string `json:"Font"`
+}
+
+// ===== LOADING =====
+
+const (
+	DefaultMapPath      = "data/yellowstone_animals.json"
+	RedHerringFactsPath = "red_herring_facts.json"
+)
+
+// FileExists reports whether anything is at path.
+func FileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// LoadAnimalsFromJSON gives a run its own animals from the map at path, and the
+// map's top level.
+func LoadAnimalsFromJSON(path string) (map[string]*Animal, int) {
+	r := LoadRoster(path)
+	animals := make(map[string]*Animal, len(r.Animals))
+	for name, a := range r.Animals {
+		animals[name] = &a
+	}
+	return animals, r.MaxLevel
+}
+
+func LoadMapConfig(path string) MapConfig {
+	var cfg MapConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+	var raw struct {
+		Config MapConfig `json:"Config"`
+	}
+	if json.Unmarshal(data, &raw) == nil {
+		cfg = raw.Config
+	}
+	return cfg
+}
+
+func LoadRedHerringFacts(path string) map[string]RedHerringInfo {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return map[string]RedHerringInfo{}
+	}
+	var out map[string]RedHerringInfo
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+func NewGameState(mapPath string) *GameState {
+	animals, max := LoadAnimalsFromJSON(mapPath)
+	cfg := LoadMapConfig(mapPath)
+	modifiers, err := CompileModifiers(cfg.Modifiers)
+	if err != nil {
+		slog.Error("map modifiers invalid", "map", mapPath, "err", err)
+	}
+
+	state := &GameState{
+		Animals:  animals,
+		MaxLevel: max,
+		Virus: &Virus{
+			Modes:    []string{"Bite"},
+			Strength: 1.0,
+		},
+		RedFacts:     LoadRedHerringFacts(RedHerringFactsPath),
+		Conservation: LoadConservation(ConservationPath),
+		Stats:        Stats{StartTime: time.Now()},
+		BrokenPhases: map[string]int{},
+		Wary:         map[string]WaryStatus{},
+		Cooldowns:    map[string]int{},
+
+		FailStreak:      map[string]int{},
+		AdviceDismissed: map[string]bool{},
+		Moods:           map[string]MoodStatus{},
+		Vaccinated:      map[string]bool{},
+		Immune:          map[string]int{},
+		Latched:         map[string]int{},
+		Priorities:      map[string]bool{},
+		Upgrades:        map[string]int{},
+
+		LastInteraction: map[string]string{},
+		Genetics:        map[string]GeneticShift{},
+		MapPath:         mapPath,
+		MapConfig:       cfg,
+		Modifiers:       modifiers,
+	}
+	SeedRun(state, time.Now().UnixNano())
+
+	if cfg.Script != "" {
+		script, err := LoadMapScript(state, ScriptPath(mapPath, cfg.Script))
+		if err != nil {
+			slog.Error("map script failed", "map", mapPath, "err", err)
+		}
+		state.script = script
+	}
+	return state
+}
+
+// SeedRun restarts state's random generator from seed. Runs with the same seed, map,
+// rules and moves roll the same way.
+func SeedRun(state *GameState, seed int64) {
+	state.Seed = seed
+	RecordDraws(state, rand.NewSource(seed))
+}
+
+// ReloadMap swaps in state's map as it is now on disk, for map authors editing a map
+// mid-run. The run keeps its infections, its juveniles, its NG+ scaling and what wild
+// genetics did to each animal; the map's config, modifiers and script are rebuilt. A
+// map that no longer loads, or whose modifiers do not compile, leaves state alone.
+func ReloadMap(state *GameState) error {
+	fresh, max := LoadAnimalsFromJSON(state.MapPath)
+	if len(fresh) == 0 {
+		return fmt.Errorf("the map %s is empty or invalid", state.MapPath)
+	}
+	cfg := LoadMapConfig(state.MapPath)
+	modifiers, err := CompileModifiers(cfg.Modifiers)
+	if err != nil {
+		return fmt.Errorf("map modifiers invalid: %w", err)
+	}
+
+	scale := math.Pow(NGPlusRateFactor, float64(state.NGPlus))
+	for name, a := range state.Animals {
+		f, ok := fresh[name]
+		if !ok {
+			if a.Infected || a.Juvenile {
+				fresh[name] = a
+			}
+			continue
+		}
+		f.InfectionRate *= scale
+		carryGenetics(state, a, f)
+		f.Infected = a.Infected
+	}
+	for name := range state.Genetics {
+		if _, ok := fresh[name]; !ok {
+			delete(state.Genetics, name)
+		}
+	}
+
+	state.Animals = fresh
+	state.MaxLevel = max
+	state.MapConfig = cfg
+	state.Modifiers = modifiers
+	if cfg.Script == "" {
+		state.script = nil
+		return nil
+	}
+	script, err := LoadMapScript(state, ScriptPath(state.MapPath, cfg.Script))
+	if err != nil {
+		slog.Error("map script failed, keeping the old one", "map", state.MapPath, "err", err)
+		return nil
+	}
+	state.script = script
+	return nil
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReloadMap edits a map mid-run and checks that the run keeps its infections and
+// its wild genetics while the map's new rates and modifiers take effect.
+func TestReloadMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reload.json")
+	if err := os.WriteFile(path, []byte(strandedMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state := NewGameState(path)
+	state.Rules = RulesConfig{WildGenetics: true}
+	state.MapConfig.Genetics = GeneticsRange{RateSpread: 0.2}
+	SeedRun(state, 1)
+	if err := ChooseStarter(state, state.Animals["Mouse"]); err != nil {
+		t.Fatal(err)
+	}
+	factor := state.Animals["Fox"].InfectionRate / state.Genetics["Fox"].BaseRate
+
+	edited := strings.Replace(strandedMap, `"InfectionRate": 0.5, "Location": "Meadow", "Contacts": ["Mouse"]`, `"InfectionRate": 0.25, "Location": "Meadow", "Contacts": ["Mouse"]`, 1)
+	edited = strings.Replace(edited, "{", `{"Config": {"Modifiers": ["chance * 0.5"]},`, 1)
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReloadMap(state); err != nil {
+		t.Fatal(err)
+	}
+
+	if !state.Animals["Mouse"].Infected || state.PlayerName != "Mouse" {
+		t.Error("patient zero lost its infection")
+	}
+	if got, want := state.Animals["Fox"].InfectionRate, 0.25*factor; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Fox rate %v, want %v", got, want)
+	}
+	if got := state.Genetics["Fox"].BaseRate; got != 0.25 {
+		t.Errorf("Fox base rate %v, want 0.25", got)
+	}
+	if len(state.Modifiers) != 1 {
+		t.Errorf("%d modifiers, want 1", len(state.Modifiers))
+	}
+}
+
+// TestResumeDraws checks that a run reseeded from its seed and its draw count rolls on
+// exactly as the uninterrupted run does.
+func TestResumeDraws(t *testing.T) {
+	state := &GameState{}
+	SeedRun(state, 42)
+	for range 17 {
+		state.RNG.Float64()
+	}
+	resumed := &GameState{Seed: state.Seed}
+	ResumeDraws(resumed, DrawsUsed(state))
+	for i := range 5 {
+		if got, want := resumed.RNG.Intn(1000), state.RNG.Intn(1000); got != want {
+			t.Fatalf("draw %d after resuming is %d, want %d", i, got, want)
+		}
+	}
+	if DrawsUsed(resumed) != DrawsUsed(state) {
+		t.Errorf("resumed run counts %d draws, want %d", DrawsUsed(resumed), DrawsUsed(state))
+	}
+}
+
+// TestSnapshotSaves checks that a practice branch read back from JSON restores the run
+// it was taken from.
+func TestSnapshotSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "branch.json")
+	if err := os.WriteFile(path, []byte(strandedMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state := NewGameState(path)
+	SeedRun(state, 1)
+	if err := ChooseStarter(state, state.Animals["Mouse"]); err != nil {
+		t.Fatal(err)
+	}
+	SaveBranch(state, "start")
+	data, err := json.Marshal(state.Branches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := NewGameState(path)
+	var branches []*Snapshot
+	if err := json.Unmarshal(data, &branches); err != nil {
+		t.Fatal(err)
+	}
+	fresh.Branches = branches
+	if !RevertToBranch(fresh, "start") {
+		t.Fatal("saved branch not found")
+	}
+	if fresh.PlayerName != "Mouse" || !fresh.Animals["Mouse"].Infected {
+		t.Errorf("restored host %q, want an infected Mouse", fresh.PlayerName)
+	}
+	fresh.Cooldowns["Fox"] = 1 // restored maps must be writable
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// ===== TIME PENALTY =====
+//
+// Time played costs points along a curve rather than at a flat rate, so a player who
+// stops to read a fact is not punished for it: the first GraceSeconds are free, after
+// that every SecondsPerPoint costs a point, and the penalty stops growing at MaxPenalty.
+// Maps set the curve under "Config" → "Scoring".
+
+const (
+	defaultGraceSeconds    = 60
+	defaultSecondsPerPoint = 2
+	defaultMaxPenalty      = 300
+)
+
+// ScoringConfig holds a map's time penalty curve. Zero fields use the defaults; a
+// negative GraceSeconds means no grace period and a negative MaxPenalty no cap.
+type ScoringConfig struct {
+	GraceSeconds    float64 `json:"GraceSeconds"`
+	SecondsPerPoint float64 `json:"SecondsPerPoint"`
+	MaxPenalty      float64 `json:"MaxPenalty"`
+}
+
+// ScoringOf is state's map's time penalty curve with defaults filled in.
+func ScoringOf(state *GameState) ScoringConfig {
+	c := state.MapConfig.Scoring
+	switch {
+	case c.GraceSeconds < 0:
+		c.GraceSeconds = 0
+	case c.GraceSeconds == 0:
+		c.GraceSeconds = defaultGraceSeconds
+	}
+	if c.SecondsPerPoint <= 0 {
+		c.SecondsPerPoint = defaultSecondsPerPoint
+	}
+	if c.MaxPenalty == 0 {
+		c.MaxPenalty = defaultMaxPenalty
+	}
+	return c
+}
+
+func (c ScoringConfig) capped() bool { return c.MaxPenalty > 0 }
+
+// Penalty is the points taken off for secs seconds played.
+func (c ScoringConfig) Penalty(secs float64) float64 {
+	p := max(0, secs-c.GraceSeconds) / c.SecondsPerPoint
+	if c.capped() {
+		p = min(p, c.MaxPenalty)
+	}
+	return p
+}
+
+// Tier describes where secs falls on the curve, for the HUD.
+func (c ScoringConfig) Tier(secs float64) string {
+	switch {
+	case secs < c.GraceSeconds:
+		return fmt.Sprintf("⏳ Grace: %.0fs free", c.GraceSeconds-secs)
+	case c.capped() && c.Penalty(secs) >= c.MaxPenalty:
+		return fmt.Sprintf("⏳ Capped: −%.0f, time no longer costs", c.MaxPenalty)
+	}
+	return fmt.Sprintf("⏳ −1 per %gs: −%.0f so far", c.SecondsPerPoint, c.Penalty(secs))
+}
+
+// Describe is the curve in words, for the rules reference.
+func (c ScoringConfig) Describe() string {
+	s := fmt.Sprintf("−1 for every %g seconds played", c.SecondsPerPoint)
+	if c.GraceSeconds > 0 {
+		s = fmt.Sprintf("The first %g seconds are free, then −1 for every %g seconds", c.GraceSeconds, c.SecondsPerPoint)
+	}
+	if c.capped() {
+		return fmt.Sprintf("%s, up to −%g.", s, c.MaxPenalty)
+	}
+	return s + "."
+}
+
+// PenaltyTier is the HUD line for the current time penalty.
+func PenaltyTier(state *GameState) string {
+	return ScoringOf(state).Tier(state.Stats.Clock.Elapsed().Seconds())
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+)
+
+// ===== VERSION STAMPS =====
+
+// EngineVersion is bumped on every release that changes game behavior.
+const EngineVersion = "0.9.0"
+
+// Stamp identifies the engine, rules and map a recording was made with, so stale
+// recordings can be detected and results partitioned by ruleset.
+type Stamp struct {
+	EngineVersion string `json:"engine_version"`
+	RulesetHash   string `json:"ruleset_hash"`
+	MapHash       string `json:"map_hash"`
+}
+
+// rulesetFingerprint lists every tuning constant that affects outcomes. Add new rule
+// constants here so that changing them changes RulesetHash.
+func rulesetFingerprint() string {
+	return fmt.Sprint(
+		"score:", ScoreBase, ScoreNextLevelBonus, ScoreSameLevelPenalty, ScoreAttemptPenalty,
+		" time:", defaultGraceSeconds, defaultSecondsPerPoint, defaultMaxPenalty,
+		" season:", DaysPerSeason,
+		" fail:", FailFleeChance, FailWaryChance, WaryDays, FleeDays, CooldownDays,
+		" ngplus:", NGPlusRateFactor,
+		" juvenile:", JuvenileRateBonus,
+		" mercy:", MercyScoreMultiplier,
+		" mood:", MoodDecayDays, MoodAlertFactor, MoodPanickedFactor,
+		" crit:", defaultCritSuccess, defaultCritFailure, CritHostDamage,
+		" latch:", LatchChanceTries, TakeoverFactor,
+		" burst:", BurstChanceFactor, BurstStrengthFactor, BurstPenaltyDays,
+		" spread:", SpreadFactor, PriorityBonus, PriorityLimit,
+		" mutations:", MutationPointsLevelUp, MutationPointsSameLevel, airborneFlyFactor, strengthStep, stealthIntelligence, stealthFactor,
+		" immunity:", RecoveryPerIntelligence, MaxRecoveryChance,
+		" symbiote:", SymbioteBonus,
+		" curses:", Curses,
+	)
+}
+
+func RulesetHash() string {
+	return shortHash([]byte(rulesetFingerprint()))
+}
+
+// MapHash hashes the raw map file and its script, if any; an unreadable map hashes as
+// empty.
+func MapHash(path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if script := LoadMapConfig(path).Script; script != "" {
+		src, _ := ioutil.ReadFile(ScriptPath(path, script))
+		data = append(data, src...)
+	}
+	return shortHash(data)
+}
+
+func shortHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
+
+func CurrentStamp(mapPath string) Stamp {
+	return Stamp{
+		EngineVersion: EngineVersion,
+		RulesetHash:   RulesetHash(),
+		MapHash:       MapHash(mapPath),
+	}
+}
+
+// Mismatch describes how s differs from the running build, or returns "" if it matches.
+func (s Stamp) Mismatch(current Stamp) string {
+	switch {
+	case s.EngineVersion != current.EngineVersion:
+		return fmt.Sprintf("engine %s, running %s", s.EngineVersion, current.EngineVersion)
+	case s.RulesetHash != current.RulesetHash:
+		return fmt.Sprintf("ruleset %s, running %s", s.RulesetHash, current.RulesetHash)
+	case s.MapHash != current.MapHash:
+		return fmt.Sprintf("map %s, running %s", s.MapHash, current.MapHash)
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ===== WEEKLY MUTATION =====
+
+type WeeklyMutation struct {
+	// Week is the ISO week, e.g. "2026-W42".
+	Week        string `json:"week"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Modifier    string `json:"modifier"`
+}
+
+// WeeklyMutations are the mutations weeks without a feed entry choose from.
+var WeeklyMutations = []WeeklyMutation{
+	{Name: "Grounded", Description: "Flying animals resist 20% more.", Modifier: "if target.Mobility == 'Fly' then chance*0.8"},
+	{Name: "Night Shift", Description: "Attempts at night are 20% likelier, by day 10% less.", Modifier: "if phase == 'Night' then chance*1.2 else chance*0.9"},
+	{Name: "Deep Freeze", Description: "Every animal resists 25% more in winter.", Modifier: "if season == 'Winter' then chance*0.75"},
+	{Name: "River Fever", Description: "Animals by the water are 25% easier to infect.", Modifier: "if target.Location == 'River' or target.Location == 'Riverbank' or target.Location == 'Marsh' then chance*1.25"},
+	{Name: "Underdog", Description: "Reaching a level up is 15% likelier.", Modifier: "if target.Level > host.Level then chance*1.15"},
+	{Name: "Burrowers' Plague", Description: "Burrowing animals are 30% easier to infect.", Modifier: "if target.Mobility == 'Burrow' then chance*1.3"},
+	{Name: "Slow Start", Description: "The first five days are 15% harder.", Modifier: "if day <= 5 then chance*0.85"},
+}
+
+// Active reports whether w is a mutation at all; the zero value means none is played.
+func (w WeeklyMutation) Active() bool { return w.Modifier != "" }
+
+func (w WeeklyMutation) Label() string {
+	return fmt.Sprintf("%s: %s", w.Week, w.Name)
+}
+
+// weeklyCompiled caches compiled mutation modifiers by source.
+var weeklyCompiled struct {
+	sync.Mutex
+	m map[string]*Modifier
+}
+
+// weeklyModifier is the compiled modifier of the run's mutation, or nil if it has none
+// or it does not compile.
+func weeklyModifier(w WeeklyMutation) *Modifier {
+	if !w.Active() {
+		return nil
+	}
+	weeklyCompiled.Lock()
+	defer weeklyCompiled.Unlock()
+	if m, ok := weeklyCompiled.m[w.Modifier]; ok {
+		return m
+	}
+	m, err := CompileModifier(w.Modifier)
+	if err != nil {
+		slog.Error("weekly mutation invalid", "week", w.Week, "err", err)
+	}
+	if weeklyCompiled.m == nil {
+		weeklyCompiled.m = map[string]*Modifier{}
+	}
+	weeklyCompiled.m[w.Modifier] = m
+	return m
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"yellowstone_evolution/engine"
+)
+
+// ===== STATS EXPORT =====
+//
+// Every finished run is added to runs.json in the profile, which the stats screen reads
+// and which can be saved as a CSV. A class can also collect runs centrally: export.json
+// in the config directory may name a webhook that gets each run as a JSON POST. A Google
+// Sheets Apps Script web app that appends its body as a row works as the endpoint.
+
+const (
+	exportConfigPath = "export.json"
+	exportTimeout    = 10 * time.Second
+)
+
+// ExportConfig is read from export.json in the config directory.
+type ExportConfig struct {
+	// Student identifies whose runs these are, e.g. a name or class number.
+	Student string `json:"student"`
+	// Webhook, if set, receives every run as a JSON POST.
+	Webhook string `json:"webhook"`
+}
+
+func LoadExportConfig() ExportConfig {
+	var cfg ExportConfig
+	if dataDirs.Config == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(filepath.Join(dataDirs.Config, exportConfigPath))
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		slog.Warn("export config unreadable", "err", err)
+	}
+	return cfg
+}
+
+// RunRecord is one finished run as exported.
+type RunRecord struct {
+	ID          int64     `json:"id"`
+	Date        time.Time `json:"date"`
+	Student     string    `json:"student,omitempty"`
+	Map         string    `json:"map"`
+	Seed        int64     `json:"seed"`
+	Won         bool      `json:"won"`
+	Starter     string    `json:"starter"`
+	Host        string    `json:"host"`
+	Level       int       `json:"level"`
+	Day         int       `json:"day"`
+	Attempts    int       `json:"attempts"`
+	Score       int       `json:"score"`
+	GameSeconds int       `json:"game_seconds"`
+	Rules       string    `json:"rules,omitempty"`
+	// Ruleset is every rule of the run; runs recorded before it was added only have Rules.
+	Ruleset *engine.RulesConfig `json:"ruleset,omitempty"`
+	Tags    []string            `json:"tags,omitempty"`
+	// Lineage is the chain of hosts, patient zero first.
+	Lineage []string `json:"lineage,omitempty"`
+	// Stamp is the engine, ruleset and map the run was played with; runs recorded
+	// before it was added have none.
+	Stamp  engine.Stamp `json:"stamp"`
+	NGPlus int          `json:"ng_plus,omitempty"`
+
+	// Signature is set by signEntry; Tampered is set on load when it does not verify.
+	Signature string `json:"signature,omitempty"`
+	Tampered  bool   `json:"-"`
+}
+
+var runRecordHeader = []string{"date", "student", "map", "seed", "won", "starter", "host", "level", "day", "attempts", "score", "game_seconds", "rules", "tags"}
+
+func (r RunRecord) row() []string {
+	return []string{
+		r.Date.Format(time.RFC3339), r.Student, r.Map, strconv.FormatInt(r.Seed, 10), strconv.FormatBool(r.Won),
+		r.Starter, r.Host, strconv.Itoa(r.Level), strconv.Itoa(r.Day), strconv.Itoa(r.Attempts),
+		strconv.Itoa(r.Score), strconv.Itoa(r.GameSeconds), r.Rules, strings.Join(r.Tags, ","),
+	}
+}
+
+func newRunRecord(state *engine.GameState, cfg ExportConfig) RunRecord {
+	now := time.Now()
+	rules := state.Rules
+	r := RunRecord{
+		ID:          now.UnixNano(),
+		Date:        now,
+		Student:     cfg.Student,
+		Map:         filepath.Base(state.MapPath),
+		Seed:        state.Seed,
+		Won:         engine.HasWon(state),
+		Starter:     state.Starter,
+		Host:        state.PlayerName,
+		Day:         state.CurrentDay,
+		Attempts:    state.Stats.Attempts,
+		Score:       engine.CalculateScore(state),
+		GameSeconds: int(state.Stats.Clock.Elapsed().Seconds()),
+		Rules:       state.Rules.Disclosure(),
+		Ruleset:     &rules,
+		Stamp:       engine.CurrentStamp(state.MapPath),
+		NGPlus:      state.NGPlus,
+	}
+	if state.Rules.Practice {
+		r.Tags = []string{"practice"}
+	}
+	if host := state.Animals[state.PlayerName]; host != nil {
+		r.Level = host.Level
+	}
+	for _, s := range state.Lineage {
+		r.Lineage = append(r.Lineage, s.Name)
+	}
+	return r
+}
+
+func runsPath() string {
+	return filepath.Join(dataDirs.Profile, "runs.json")
+}
+
+// LoadRuns returns every recorded run, oldest first; a missing file means none. Runs
+// whose signature no longer verifies are marked Tampered.
+func LoadRuns() []RunRecord {
+	data, err := os.ReadFile(runsPath())
+	if err != nil {
+		return nil
+	}
+	var out []RunRecord
+	if err := json.Unmarshal(data, &out); err != nil {
+		slog.Error("run history unreadable", "err", err)
+		return nil
+	}
+	for i := range out {
+		out[i].Tampered = out[i].Signature != "" && !verifyEntry(out[i])
+	}
+	return out
+}
+
+func saveRuns(runs []RunRecord) error {
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(runsPath(), data, 0o644)
+}
+
+// exportRun logs the finished run's seed, records the run in runs.json and, in the
+// background, posts it to the configured webhook. It remembers the run's ID so tags can
+// be added afterwards, and drops the saved game, which has nothing left to continue.
+func exportRun(state *engine.GameState) {
+	ClearSave()
+	cfg := LoadExportConfig()
+	r := newRunRecord(state, cfg)
+	state.RunID = r.ID
+	slog.Info("run over", "won", r.Won, "map", r.Map, "seed", r.Seed)
+	if path, err := saveReplay(state); err != nil {
+		slog.Error("replay not saved", "err", err)
+	} else if path != "" {
+		slog.Info("replay saved", "path", path)
+	}
+
+	if dataDirs.Profile != "" {
+		if sig, err := signEntry(r); err != nil {
+			slog.Error("run not signed", "err", err)
+		} else {
+			r.Signature = sig
+		}
+		if err := saveRuns(append(LoadRuns(), r)); err != nil {
+			slog.Error("run export failed", "err", err)
+		}
+	}
+	if cfg.Webhook != "" {
+		go func() {
+			if err := postJSON(cfg.Webhook, r); err != nil {
+				slog.Error("run export failed", "err", err)
+			}
+		}()
+	}
+}
+
+func writeRunsCSV(w io.Writer, runs []RunRecord) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(runRecordHeader)
+	for _, r := range runs {
+		_ = cw.Write(r.row())
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// postJSON posts v to url as JSON, as run exports and webhooks do.
+func postJSON(url string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: exportTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// exportButton saves the runs chosen by runs as a CSV wherever the player chooses.
+func exportButton(win fyne.Window, runs func() []RunRecord) fyne.CanvasObject {
+	return newButton("📊 Save run stats (CSV)…", func() {
+		list := runs()
+		if len(list) == 0 {
+			dialog.ShowError(fmt.Errorf("no runs recorded yet"), win)
+			return
+		}
+		save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if w == nil {
+				return
+			}
+			defer w.Close()
+			if err := writeRunsCSV(w, list); err != nil {
+				dialog.ShowError(err, win)
+			}
+		}, win)
+		save.SetFileName("rawr-runs.csv")
+		save.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+		save.Show()
+	})
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== FEEDBACK AND BUG REPORTS =====
+//
+// The feedback form bundles the player's description with what a maintainer needs to
+// reproduce a problem: the version stamp, seed and rules, a dump of the run's state and
+// the log file. The bundle is a zip that can be saved locally, posted to the endpoint
+// named in feedback.json in the config directory, or attached to a GitHub issue that
+// the form opens pre-filled.
+
+const (
+	feedbackConfigPath = "feedback.json"
+	defaultIssueURL    = "https://github.com/anaymody/rAAwr/issues/new"
+)
+
+type FeedbackConfig struct {
+	// Endpoint, if set, receives report bundles as a zip POST.
+	Endpoint string `json:"endpoint"`
+	// IssueURL is the new-issue page opened for GitHub reports.
+	IssueURL string `json:"issue_url"`
+}
+
+func LoadFeedbackConfig() FeedbackConfig {
+	cfg := FeedbackConfig{IssueURL: defaultIssueURL}
+	if dataDirs.Config == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(filepath.Join(dataDirs.Config, feedbackConfigPath))
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		slog.Warn("feedback config unreadable", "err", err)
+	}
+	if cfg.IssueURL == "" {
+		cfg.IssueURL = defaultIssueURL
+	}
+	return cfg
+}
+
+// StateDump is a readable copy of a run's state for bug reports.
+type StateDump struct {
+	Map         string                `json:"map"`
+	Day         int                   `json:"day"`
+	Phase       string                `json:"phase"`
+	Host        string                `json:"host"`
+	Starter     string                `json:"starter"`
+	Attempts    int                   `json:"attempts"`
+	Strength    float64               `json:"strength"`
+	Infected    []string              `json:"infected"`
+	Broken      map[string]int        `json:"broken_phases,omitempty"`
+	Latched     map[string]int        `json:"latched,omitempty"`
+	Immune      map[string]int        `json:"immune,omitempty"`
+	Cooldowns   map[string]int        `json:"cooldowns,omitempty"`
+	Moods       map[string]string     `json:"moods,omitempty"`
+	Effects     []engine.TimedEffect  `json:"effects,omitempty"`
+	NGPlus      int                   `json:"ng_plus,omitempty"`
+	Lineage     []engine.HostStep     `json:"lineage,omitempty"`
+	Transmitted []engine.Transmission `json:"transmissions,omitempty"`
+	DayReport   []string              `json:"day_report,omitempty"`
+	BurstUsed   bool                  `json:"burst_used,omitempty"`
+	Vaccinated  []string              `json:"vaccinated,omitempty"`
+	Upgrades    map[string]int        `json:"upgrades,omitempty"`
+}
+
+func dumpState(state *engine.GameState) StateDump {
+	d := StateDump{
+		Map:         state.MapPath,
+		Day:         state.CurrentDay,
+		Phase:       state.Phase.String(),
+		Host:        state.PlayerName,
+		Starter:     state.Starter,
+		Attempts:    state.Stats.Attempts,
+		Strength:    engine.VirusStrength(state),
+		Broken:      state.BrokenPhases,
+		Latched:     state.Latched,
+		Immune:      state.Immune,
+		Cooldowns:   state.Cooldowns,
+		Moods:       map[string]string{},
+		Effects:     state.Effects,
+		NGPlus:      state.NGPlus,
+		Lineage:     state.Lineage,
+		Upgrades:    state.Upgrades,
+		Transmitted: state.Transmissions,
+		DayReport:   state.DayReport,
+		BurstUsed:   state.BurstUsed,
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		if state.Animals[name].Infected {
+			d.Infected = append(d.Infected, name)
+		}
+		if state.Vaccinated[name] {
+			d.Vaccinated = append(d.Vaccinated, name)
+		}
+	}
+	for name, m := range state.Moods {
+		d.Moods[name] = m.Mood.String()
+	}
+	return d
+}
+
+// FeedbackReport is report.json in the bundle.
+type FeedbackReport struct {
+	Description string             `json:"description"`
+	Date        time.Time          `json:"date"`
+	Stamp       engine.Stamp       `json:"stamp"`
+	Seed        int64              `json:"seed"`
+	Rules       engine.RulesConfig `json:"rules"`
+	Platform    string             `json:"platform"`
+}
+
+func newFeedbackReport(state *engine.GameState, description string) FeedbackReport {
+	return FeedbackReport{
+		Description: description,
+		Date:        time.Now(),
+		Stamp:       engine.CurrentStamp(state.MapPath),
+		Seed:        state.Seed,
+		Rules:       state.Rules,
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// feedbackBundle zips the report with the state dump and, if withLog, the log files.
+func feedbackBundle(state *engine.GameState, report FeedbackReport, withLog bool) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	addJSON := func(name string, v any) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	if err := addJSON("report.json", report); err != nil {
+		return nil, err
+	}
+	if err := addJSON("state.json", dumpState(state)); err != nil {
+		return nil, err
+	}
+	if withLog && logDir() != "" {
+		for n := 0; n <= logKeep; n++ {
+			data, err := os.ReadFile(logPath(n))
+			if err != nil {
+				continue
+			}
+			w, err := zw.Create(filepath.Base(logPath(n)))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := w.Write(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func postFeedback(endpoint string, bundle []byte) error {
+	client := &http.Client{Timeout: exportTimeout}
+	resp, err := client.Post(endpoint, "application/zip", bytes.NewReader(bundle))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("feedback endpoint answered %s", resp.Status)
+	}
+	return nil
+}
+
+// issueURL pre-fills a GitHub new-issue page with the report; the bundle itself has to
+// be attached by hand.
+func issueURL(base string, report FeedbackReport) (*url.URL, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	body := fmt.Sprintf("%s\n\n---\nVersion: %s (ruleset %s, map %s)\nSeed: %d\nPlatform: %s\n\nPlease attach the saved report bundle.",
+		report.Description, report.Stamp.EngineVersion, report.Stamp.RulesetHash, report.Stamp.MapHash, report.Seed, report.Platform)
+	q := u.Query()
+	q.Set("title", "Bug report")
+	q.Set("body", body)
+	u.RawQuery = q.Encode()
+	return u, nil
+}
+
+// showFeedbackDialog opens the feedback form for the run in state.
+func showFeedbackDialog(state *engine.GameState, win fyne.Window) {
+	cfg := LoadFeedbackConfig()
+	state.Stats.Clock.Pause()
+
+	description := widget.NewMultiLineEntry()
+	description.SetPlaceHolder("What happened, and what did you expect?")
+	description.SetMinRowsVisible(6)
+	withLog := widget.NewCheck("Attach the log file", nil)
+	withLog.SetChecked(true)
+
+	bundle := func() ([]byte, FeedbackReport, error) {
+		report := newFeedbackReport(state, description.Text)
+		data, err := feedbackBundle(state, report, withLog.Checked)
+		return data, report, err
+	}
+
+	save := newButton("💾 Save bundle…", func() {
+		data, _, err := bundle()
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		d := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if w == nil {
+				return
+			}
+			defer w.Close()
+			if _, err := w.Write(data); err != nil {
+				dialog.ShowError(err, win)
+			}
+		}, win)
+		d.SetFileName(fmt.Sprintf("rawr-report-%s.zip", time.Now().Format("20060102-150405")))
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+		d.Show()
+	})
+
+	send := newButton("📨 Send", func() {
+		data, _, err := bundle()
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		go func() {
+			err := postFeedback(cfg.Endpoint, data)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				dialog.ShowInformation("Report Sent", "Thank you for the report.", win)
+			})
+		}()
+	})
+	if cfg.Endpoint == "" {
+		send.Hide()
+	}
+
+	issue := newButton("🐙 Open GitHub issue", func() {
+		_, report, _ := bundle()
+		u, err := issueURL(cfg.IssueURL, report)
+		if err == nil {
+			err = fyne.CurrentApp().OpenURL(u)
+		}
+		if err != nil {
+			dialog.ShowError(err, win)
+		}
+	})
+
+	stamp := engine.CurrentStamp(state.MapPath)
+	info := newLabel(fmt.Sprintf("Sent with the report: version %s, seed %d, rules and the state of this run.", stamp.EngineVersion, state.Seed))
+	info.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(description, withLog, info, container.NewHBox(save, send, issue))
+	d := dialog.NewCustom(plain("🐞 Report a Problem"), "Close", content, win)
+	d.Resize(fyne.NewSize(560, 380))
+	d.SetOnClosed(state.Stats.Clock.Resume)
+	d.Show()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== FORECAST =====
+//
+// The forecast weighs every target on the board with the same chance model the game
+// rolls against: how many attempts it should take, and what that does to the score
+// once the attempt and time penalties are paid.
+
+type TargetForecast struct {
+	Animal           *engine.Animal
+	Chance           float64
+	Rolls            int
+	ExpectedAttempts float64
+	// ScoreImpact is the expected change in score from taking this target; 0 when the
+	// chance is 0.
+	ScoreImpact int
+	Blocked     string
+}
+
+// forecastTarget assumes the chance stays as it is now, so seasons turning or
+// modifiers changing with the day are not included. Taking an apex counts its ending
+// bonus, so the forecast compares the endings too.
+func forecastTarget(state *engine.GameState, a *engine.Animal) TargetForecast {
+	f := TargetForecast{Animal: a, Chance: engine.InfectionChance(state, a), Rolls: 1, Blocked: engine.BlockedReason(state, a)}
+	if phase, i := engine.CurrentResistancePhase(state, a); phase != nil {
+		f.Rolls += len(a.ResistancePhases) - i
+	}
+	if f.Chance <= 0 {
+		return f
+	}
+
+	mercy := engine.MercyApplies(state)
+	for r := 0; r < f.Rolls; r++ {
+		if mercy {
+			f.ExpectedAttempts++
+			mercy = false
+			continue
+		}
+		f.ExpectedAttempts += 1 / f.Chance
+	}
+
+	impact := -engine.ScoreSameLevelPenalty
+	if host := state.Animals[state.PlayerName]; host != nil && a.Level > host.Level {
+		impact = engine.ScoreNextLevelBonus + engine.EndingBonus(state, a)
+	}
+	// The time cost is what the extra seconds add to the penalty from where the run is now.
+	curve, now := engine.ScoringOf(state), state.Stats.Clock.Elapsed().Seconds()
+	secs := f.ExpectedAttempts * planSecondsPerAttempt
+	score := float64(impact) - f.ExpectedAttempts*engine.ScoreAttemptPenalty - (curve.Penalty(now+secs) - curve.Penalty(now))
+	f.ScoreImpact = int(score * state.Rules.ScoreMultiplier())
+	return f
+}
+
+func forecastTargets(state *engine.GameState) []TargetForecast {
+	var out []TargetForecast
+	for _, a := range engine.CandidateTargets(state) {
+		out = append(out, forecastTarget(state, a))
+	}
+	return out
+}
+
+// forecastPanel is a collapsed panel in the game header listing forecastTargets.
+func forecastPanel(state *engine.GameState) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(5,
+		widget.NewLabelWithStyle("Target", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Chance", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Attempts", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Score", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("Now", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	for _, f := range forecastTargets(state) {
+		attempts, impact := "—", "—"
+		if f.Chance > 0 && !engine.Blinded(state) {
+			attempts = fmt.Sprintf("%.1f", f.ExpectedAttempts)
+			impact = fmt.Sprintf("%+d", f.ScoreImpact)
+		}
+		now := f.Blocked
+		if now == "" {
+			now = "Ready"
+		}
+		name := f.Animal.Name
+		if engine.EndingBonus(state, f.Animal) != 0 || (f.Animal.Level == state.MaxLevel && engine.EndingOf(f.Animal).Title != "") {
+			name = "👑 " + engine.EndingLine(f.Animal)
+		}
+		grid.Add(newLabel(name))
+		grid.Add(widget.NewLabelWithStyle(engine.ChanceText(state, f.Chance), fyne.TextAlignTrailing, fyne.TextStyle{}))
+		grid.Add(widget.NewLabelWithStyle(attempts, fyne.TextAlignTrailing, fyne.TextStyle{}))
+		grid.Add(widget.NewLabelWithStyle(impact, fyne.TextAlignTrailing, fyne.TextStyle{}))
+		grid.Add(newLabel(now))
+	}
+	return widget.NewAccordion(widget.NewAccordionItem(plain("📈 Forecast"), grid))
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== HIGH SCORES =====
+//
+// The high score table is the won, ranked runs of the run history in runs.json: score,
+// host chain, game time, seed and rules are all recorded with each run when it ends.
+// The High Scores screen and "rawr scores" rank them and filter them by map. Runs
+// edited by hand no longer verify (see signing.go) and are left off the table.
+
+// highScoreLimit is how many runs the table lists by default.
+const highScoreLimit = 20
+
+// HighScoreSorts are the orders the table can be ranked in; the first is the default.
+var HighScoreSorts = []string{"score", "time", "attempts", "date"}
+
+// highScores lists the won, ranked runs on mapName ("" for every map) in the order
+// named by by, at most limit of them (0 for all). Tampered runs are never ranked.
+func highScores(runs []RunRecord, mapName, by string, limit int) []RunRecord {
+	var out []RunRecord
+	for _, r := range runs {
+		if !r.Won || r.Tampered || slices.Contains(r.Tags, "practice") || (r.Ruleset != nil && r.Ruleset.Practice) {
+			continue
+		}
+		if mapName != "" && r.Map != mapName {
+			continue
+		}
+		out = append(out, r)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		switch by {
+		case "time":
+			return a.GameSeconds < b.GameSeconds
+		case "attempts":
+			return a.Attempts < b.Attempts
+		case "date":
+			return a.Date.After(b.Date)
+		default:
+			return a.Score > b.Score
+		}
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// highScoreLine is one row of the table, e.g. "1. 4120 — yellowstone_animals.json —
+// Deer Mouse → Red Fox → Gray Wolf — 312s, 18 attempts — seed 42 — standard, NG+1 —
+// engine 0.9.0, ruleset 3f2a9c0d41be".
+func highScoreLine(rank int, r RunRecord) string {
+	chain := strings.Join(r.Lineage, " → ")
+	if chain == "" {
+		chain = r.Starter + " … " + r.Host
+	}
+	line := fmt.Sprintf("%d. %d — %s — %s — %ds, %d attempts — seed %d — %s", rank, r.Score, r.Map, chain, r.GameSeconds, r.Attempts, r.Seed, difficultyOf(r))
+	if r.NGPlus > 0 {
+		line += fmt.Sprintf(", NG+%d", r.NGPlus)
+	}
+	if r.Stamp.EngineVersion != "" {
+		line += fmt.Sprintf(" — engine %s, ruleset %s", r.Stamp.EngineVersion, r.Stamp.RulesetHash)
+	}
+	if r.Student != "" {
+		line += " — " + r.Student
+	}
+	if r.Signature == "" {
+		line += " — unsigned"
+	}
+	return line
+}
+
+func createHighScoresScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	runs := LoadRuns()
+	mapName, by := filepath.Base(state.MapPath), HighScoreSorts[0]
+	var shown []RunRecord
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("🏆 High Scores"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	empty := newLabel("")
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject { return newLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(plain(highScoreLine(i+1, shown[i])))
+		},
+	)
+	refresh := func() {
+		shown = highScores(runs, mapName, by, highScoreLimit)
+		empty.SetText("")
+		if len(shown) == 0 {
+			empty.SetText("No wins on this map yet.")
+		}
+		list.Refresh()
+	}
+
+	maps := distinct(runs, func(r RunRecord) []string { return []string{r.Map} })
+	if !slices.Contains(maps, mapName) {
+		maps = append(maps, mapName)
+	}
+	mapSel := widget.NewSelect(append([]string{filterAll}, maps...), nil)
+	mapSel.SetSelected(mapName)
+	mapSel.OnChanged = func(v string) {
+		inputs.Record("scores-map:" + v)
+		mapName = v
+		if v == filterAll {
+			mapName = ""
+		}
+		refresh()
+	}
+	inputs.HandlePrefix("scores-map:", func(v string) { mapSel.SetSelected(v) })
+
+	sortSel := widget.NewSelect(HighScoreSorts, nil)
+	sortSel.SetSelected(by)
+	sortSel.OnChanged = func(v string) {
+		inputs.Record("scores-sort:" + v)
+		by = v
+		refresh()
+	}
+	inputs.HandlePrefix("scores-sort:", func(v string) { sortSel.SetSelected(v) })
+
+	refresh()
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, back, nil, title),
+		container.NewCenter(container.NewHBox(newLabel("Map"), mapSel, newLabel("Sort by"), sortSel)),
+		container.NewCenter(empty),
+	)
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, list)))
+}
+
+// runScores implements the "scores" subcommand:
+//
+//	scores [--map name] [--sort score|time|attempts|date] [--limit N] [--json]
+func runScores(args []string) int {
+	fs := flag.NewFlagSet("scores", flag.ContinueOnError)
+	mapFlag := fs.String("map", "", "only runs on this map, by file name or path (default: every map)")
+	by := fs.String("sort", HighScoreSorts[0], "rank by "+strings.Join(HighScoreSorts, ", "))
+	limit := fs.Int("limit", highScoreLimit, "number of runs to list, 0 for all")
+	asJSON := fs.Bool("json", false, "print the runs as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if !slices.Contains(HighScoreSorts, *by) {
+		fmt.Fprintf(os.Stderr, "Scores error: unknown sort %q\n", *by)
+		return 2
+	}
+	initDataDirs()
+
+	mapName := ""
+	if *mapFlag != "" {
+		mapName = filepath.Base(*mapFlag)
+	}
+	scores := highScores(LoadRuns(), mapName, *by, *limit)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(scores)
+		return 0
+	}
+	if len(scores) == 0 {
+		fmt.Println("No won runs recorded yet.")
+		return 0
+	}
+	for i, r := range scores {
+		fmt.Printf("%s (%s)\n", highScoreLine(i+1, r), r.Date.Local().Format(time.DateOnly))
+	}
+	return 0
+}
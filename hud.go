@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"yellowstone_evolution/engine"
+)
+
+// ===== DEVELOPER HUD =====
+
+const hudInterval = time.Second
+
+// DevHUD is a small overlay of performance counters, toggled with F3 or the View menu.
+// Every screen is rebuilt from scratch on each move, so the counters focus on that
+// churn: screen builds, image cache growth and how busy the UI thread is.
+type DevHUD struct {
+	mu      sync.Mutex
+	on      bool
+	started bool
+	text    *canvas.Text
+
+	builds  atomic.Int64
+	frames  atomic.Int64
+	latency time.Duration
+}
+
+var devHUD = &DevHUD{}
+
+func (h *DevHUD) SetEnabled(on bool) {
+	h.mu.Lock()
+	h.on = on
+	text := h.text
+	if on && !h.started {
+		h.started = true
+		go h.loop()
+	}
+	h.mu.Unlock()
+	if text != nil {
+		text.Hidden = !on
+		text.Refresh()
+	}
+}
+
+func (h *DevHUD) Enabled() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.on
+}
+
+func (h *DevHUD) Toggle() { h.SetEnabled(!h.Enabled()) }
+
+// countFrame is called once per rendered animation frame.
+func (h *DevHUD) countFrame() { h.frames.Add(1) }
+
+// layer wraps a freshly built screen with the HUD text, and counts the build.
+func (h *DevHUD) layer(content fyne.CanvasObject) fyne.CanvasObject {
+	h.builds.Add(1)
+
+	text := canvas.NewText("", color.NRGBA{R: 0x80, G: 0xff, B: 0x80, A: 0xff})
+	text.TextSize = 12
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+
+	h.mu.Lock()
+	h.text = text
+	text.Hidden = !h.on
+	h.mu.Unlock()
+
+	return container.NewStack(content, container.NewVBox(container.NewHBox(text, layout.NewSpacer())))
+}
+
+func (h *DevHUD) loop() {
+	last := time.Now()
+	for sleepOrShutdown(hudInterval) {
+		// Time a no-op round trip through the UI thread: a busy thread means slow frames.
+		queued := time.Now()
+		fyne.Do(func() {
+			h.mu.Lock()
+			h.latency = time.Since(queued)
+			h.mu.Unlock()
+		})
+
+		secs := time.Since(last).Seconds()
+		last = time.Now()
+		builds, frames := h.builds.Swap(0), h.frames.Swap(0)
+		count, bytes := images.Stats()
+
+		h.mu.Lock()
+		line := fmt.Sprintf("UI %.1fms · anim %.0f fps · builds %.1f/s · goroutines %d · images %d (%.1f MB) · events %d queued, %.0f/s",
+			float64(h.latency.Microseconds())/1000, float64(frames)/secs, float64(builds)/secs,
+			runtime.NumGoroutine(), count, float64(bytes)/(1<<20), engine.Events.Depth(), float64(engine.Events.TakePublished())/secs)
+		text, on := h.text, h.on
+		h.mu.Unlock()
+
+		if on && text != nil {
+			fyne.Do(func() {
+				text.Text = line
+				text.Refresh()
+			})
+		}
+	}
+}
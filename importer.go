@@ -0,0 +1,385 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== SPECIES IMPORT =====
+//
+// "rawr import" scaffolds a new map from a real species list: the species observed in an
+// iNaturalist place, or the species of a GBIF checklist dataset. Each species gets a
+// suggested level from its taxonomy (insects at the bottom, large carnivores at the top)
+// and placeholder stats to tune by hand. Photos are downloaded as portraits only when
+// their licence allows redistribution, and credited in CREDITS.txt.
+//
+// The output folder is laid out as a custom map's assets folder, so it can be loaded
+// with File → Load custom map and checked with "rawr lint".
+
+const (
+	inatSpeciesURL = "https://api.inaturalist.org/v1/observations/species_counts"
+	gbifSpeciesURL = "https://api.gbif.org/v1/species/search"
+	gbifMediaURL   = "https://api.gbif.org/v1/occurrence/search"
+	importTimeout  = 30 * time.Second
+)
+
+// importLicences are the photo licences that allow the portraits to be shipped with a
+// map. Non-commercial and no-derivatives licences are left out.
+var importLicences = []string{"cc0", "cc-by", "cc-by-sa", "pd"}
+
+var importClient = &http.Client{Timeout: importTimeout}
+
+// ImportedSpecies is a species from a remote list, before it becomes an Animal.
+type ImportedSpecies struct {
+	Name       string
+	Scientific string
+	Class      string
+	Order      string
+	Family     string
+	PhotoURL   string
+	Licence    string
+	Credit     string
+}
+
+// suggestLevel places a species on the food chain from its taxonomy. It is only a
+// starting point for the author.
+func suggestLevel(s ImportedSpecies) int {
+	switch s.Family {
+	case "Ursidae", "Felidae", "Canidae", "Accipitridae":
+		return 5
+	}
+	switch s.Order {
+	case "Carnivora", "Strigiformes", "Falconiformes", "Accipitriformes":
+		return 4
+	case "Artiodactyla", "Perissodactyla":
+		return 3
+	}
+	switch s.Class {
+	case "Insecta", "Arachnida", "Gastropoda", "Bivalvia", "Malacostraca", "Clitellata", "Mollusca":
+		return 1
+	case "Amphibia", "Actinopterygii", "Reptilia", "Squamata", "Testudines":
+		return 2
+	case "Aves", "Mammalia":
+		return 3
+	}
+	return 2
+}
+
+// importAnimal turns s into a map animal with placeholder stats for its level.
+func importAnimal(s ImportedSpecies) *engine.Animal {
+	level := suggestLevel(s)
+	mobility := "Walk"
+	switch s.Class {
+	case "Aves", "Insecta":
+		mobility = "Fly"
+	case "Actinopterygii", "Amphibia":
+		mobility = "Swim"
+	}
+	activity := engine.ActivityDiurnal
+	if s.Order == "Strigiformes" || s.Order == "Chiroptera" {
+		activity = engine.ActivityNocturnal
+	}
+	return &engine.Animal{
+		AnimalDef: &engine.AnimalDef{
+			Name:           s.Name,
+			Level:          level,
+			Mobility:       mobility,
+			ActivityPeriod: activity,
+		},
+		Intelligence:  level,
+		InfectionRate: 0.6 - 0.08*float64(level),
+	}
+}
+
+func getJSON(u string, out any) error {
+	resp, err := importClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// inatSpecies lists the most observed animal species in an iNaturalist place.
+func inatSpecies(placeID string, limit int) ([]ImportedSpecies, error) {
+	q := url.Values{
+		"place_id":      {placeID},
+		"taxon_id":      {"1"}, // Animalia
+		"rank":          {"species"},
+		"per_page":      {fmt.Sprint(limit)},
+		"quality_grade": {"research"},
+	}
+	var page struct {
+		Results []struct {
+			Taxon struct {
+				Name         string `json:"name"`
+				CommonName   string `json:"preferred_common_name"`
+				IconicTaxon  string `json:"iconic_taxon_name"`
+				DefaultPhoto *struct {
+					URL         string `json:"medium_url"`
+					Licence     string `json:"license_code"`
+					Attribution string `json:"attribution"`
+				} `json:"default_photo"`
+			} `json:"taxon"`
+		} `json:"results"`
+	}
+	if err := getJSON(inatSpeciesURL+"?"+q.Encode(), &page); err != nil {
+		return nil, err
+	}
+	var out []ImportedSpecies
+	for _, r := range page.Results {
+		t := r.Taxon
+		s := ImportedSpecies{Name: t.CommonName, Scientific: t.Name, Class: t.IconicTaxon}
+		if s.Name == "" {
+			s.Name = t.Name
+		}
+		if p := t.DefaultPhoto; p != nil {
+			s.PhotoURL, s.Licence, s.Credit = p.URL, p.Licence, p.Attribution
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// gbifSpecies lists the species of a GBIF checklist dataset, with a photo from one of
+// their occurrences where there is one.
+func gbifSpecies(datasetKey string, limit int) ([]ImportedSpecies, error) {
+	q := url.Values{
+		"datasetKey": {datasetKey},
+		"rank":       {"SPECIES"},
+		"limit":      {fmt.Sprint(limit)},
+	}
+	var page struct {
+		Results []struct {
+			Key             int64  `json:"key"`
+			NubKey          int64  `json:"nubKey"`
+			CanonicalName   string `json:"canonicalName"`
+			Kingdom         string `json:"kingdom"`
+			Class           string `json:"class"`
+			Order           string `json:"order"`
+			Family          string `json:"family"`
+			VernacularNames []struct {
+				Name     string `json:"vernacularName"`
+				Language string `json:"language"`
+			} `json:"vernacularNames"`
+		} `json:"results"`
+	}
+	if err := getJSON(gbifSpeciesURL+"?"+q.Encode(), &page); err != nil {
+		return nil, err
+	}
+	var out []ImportedSpecies
+	for _, r := range page.Results {
+		if r.Kingdom != "" && r.Kingdom != "Animalia" {
+			continue
+		}
+		s := ImportedSpecies{Name: r.CanonicalName, Scientific: r.CanonicalName, Class: r.Class, Order: r.Order, Family: r.Family}
+		for _, v := range r.VernacularNames {
+			if v.Language == "eng" {
+				s.Name = titleCase(v.Name)
+				break
+			}
+		}
+		taxon := r.NubKey
+		if taxon == 0 {
+			taxon = r.Key
+		}
+		s.PhotoURL, s.Licence, s.Credit = gbifPhoto(taxon)
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// gbifPhoto finds a freely licensed photo among a taxon's occurrences, or returns "".
+func gbifPhoto(taxonKey int64) (photo, licence, credit string) {
+	q := url.Values{
+		"taxonKey":  {fmt.Sprint(taxonKey)},
+		"mediaType": {"StillImage"},
+		"limit":     {"10"},
+	}
+	var page struct {
+		Results []struct {
+			Media []struct {
+				Identifier string `json:"identifier"`
+				License    string `json:"license"`
+				Creator    string `json:"creator"`
+				Rights     string `json:"rightsHolder"`
+			} `json:"media"`
+		} `json:"results"`
+	}
+	if err := getJSON(gbifMediaURL+"?"+q.Encode(), &page); err != nil {
+		return "", "", ""
+	}
+	for _, r := range page.Results {
+		for _, m := range r.Media {
+			if code := licenceCode(m.License); m.Identifier != "" && slices.Contains(importLicences, code) {
+				who := m.Creator
+				if who == "" {
+					who = m.Rights
+				}
+				return m.Identifier, code, who
+			}
+		}
+	}
+	return "", "", ""
+}
+
+// licenceCode normalizes a licence name or URL, e.g.
+// "http://creativecommons.org/licenses/by/4.0/" or "CC_BY_4_0", to a code like "cc-by".
+func licenceCode(licence string) string {
+	l := strings.ToLower(licence)
+	switch {
+	case strings.Contains(l, "publicdomain/zero"), strings.Contains(l, "cc0"):
+		return "cc0"
+	case strings.Contains(l, "publicdomain"), strings.Contains(l, "public domain"):
+		return "pd"
+	}
+	l = strings.NewReplacer("_", "-", "http://creativecommons.org/licenses/", "cc-", "https://creativecommons.org/licenses/", "cc-").Replace(l)
+	var parts []string
+	for _, p := range strings.FieldsFunc(l, func(r rune) bool { return r == '-' || r == '/' }) {
+		if p == "cc" || p == "by" || p == "sa" || p == "nc" || p == "nd" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r := []rune(w)
+		words[i] = strings.ToUpper(string(r[0])) + string(r[1:])
+	}
+	return strings.Join(words, " ")
+}
+
+// savePortrait downloads a photo and stores it as the PNG portrait the game expects.
+func savePortrait(photo, path string) error {
+	resp, err := importClient.Get(photo)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", photo, resp.Status)
+	}
+	img, _, err := image.Decode(resp.Body)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeImportedMap writes species to dir as map.json, with portraits under dir/png and
+// their credits in dir/CREDITS.txt. It returns how many portraits were saved.
+func writeImportedMap(dir string, species []ImportedSpecies, source string) (int, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "png"), 0o755); err != nil {
+		return 0, err
+	}
+
+	levels := map[string][]*engine.Animal{}
+	seen := map[string]bool{}
+	credits := []string{"Portraits imported from " + source + ".", ""}
+	saved := 0
+	for _, s := range species {
+		if seen[s.Name] {
+			continue
+		}
+		seen[s.Name] = true
+		a := importAnimal(s)
+		key := fmt.Sprintf("Level%d", a.Level)
+		levels[key] = append(levels[key], a)
+
+		if s.PhotoURL == "" || !slices.Contains(importLicences, licenceCode(s.Licence)) {
+			continue
+		}
+		if err := savePortrait(s.PhotoURL, filepath.Join(dir, "png", engine.Slug(a.Name)+".png")); err != nil {
+			fmt.Fprintln(os.Stderr, "Import: no portrait for", a.Name+":", err)
+			continue
+		}
+		saved++
+		credits = append(credits, fmt.Sprintf("%s (%s): %s, %s, %s", a.Name, s.Scientific, s.Credit, licenceCode(s.Licence), s.PhotoURL))
+	}
+	for _, animals := range levels {
+		sort.Slice(animals, func(i, j int) bool { return animals[i].Name < animals[j].Name })
+	}
+
+	data, err := json.MarshalIndent(levels, "", "  ")
+	if err != nil {
+		return saved, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "map.json"), data, 0o644); err != nil {
+		return saved, err
+	}
+	return saved, os.WriteFile(filepath.Join(dir, "CREDITS.txt"), []byte(strings.Join(credits, "\n")+"\n"), 0o644)
+}
+
+// runImport implements the "import" subcommand:
+//
+//	import (--inat-place ID | --gbif-dataset KEY) [--limit N] [--out dir]
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	place := fs.String("inat-place", "", "iNaturalist place ID whose most observed animal species to import")
+	dataset := fs.String("gbif-dataset", "", "GBIF checklist dataset key whose species to import")
+	limit := fs.Int("limit", 30, "most species to import")
+	out := fs.String("out", "imported_map", "folder to write map.json, png/ and CREDITS.txt to")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (*place == "") == (*dataset == "") {
+		fmt.Fprintln(os.Stderr, "usage: import (--inat-place ID | --gbif-dataset KEY) [--limit N] [--out dir]")
+		return 2
+	}
+
+	var species []ImportedSpecies
+	var source string
+	var err error
+	if *place != "" {
+		species, err = inatSpecies(*place, *limit)
+		source = "iNaturalist place " + *place
+	} else {
+		species, err = gbifSpecies(*dataset, *limit)
+		source = "GBIF dataset " + *dataset
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Import error:", err)
+		return 1
+	}
+	if len(species) == 0 {
+		fmt.Fprintln(os.Stderr, "Import error: no animal species found in", source)
+		return 1
+	}
+
+	saved, err := writeImportedMap(*out, species, source)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Import error:", err)
+		return 1
+	}
+	fmt.Printf("Imported %d species with %d portraits into %s\n", len(species), saved, *out)
+	fmt.Printf("Levels are suggestions from taxonomy; tune them, then run: rawr lint --assets %s %s\n", *out, filepath.Join(*out, "map.json"))
+	return 0
+}
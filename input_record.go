@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"yellowstone_evolution/engine"
+)
+
+// ===== INPUT RECORDING =====
+
+// InputEvent is one user action, timestamped in milliseconds since launch.
+// Actions are semantic ("infect:Red Fox", "dismiss") rather than raw pointer events,
+// so a replay drives the same handlers regardless of window size or layout.
+type InputEvent struct {
+	At     int64  `json:"at"`
+	Action string `json:"action"`
+}
+
+// InputLog is the on-disk recording: a header line with the seed, then one event per line.
+type InputLog struct {
+	Seed   int64        `json:"seed"`
+	Stamp  engine.Stamp `json:"stamp"`
+	Events []InputEvent
+}
+
+type InputRecorder struct {
+	mu       sync.Mutex
+	start    time.Time
+	handlers map[string]func()
+	// prefixed handles actions that carry free text, like "note:<text>".
+	prefixed map[string]func(arg string)
+	out      *os.File
+}
+
+var inputs = &InputRecorder{start: time.Now(), handlers: map[string]func(){}, prefixed: map[string]func(string){}}
+
+// Reset forgets the previous screen's handlers. Every screen calls it before binding its own.
+func (r *InputRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = map[string]func(){}
+	r.prefixed = map[string]func(string){}
+}
+
+// HandlePrefix registers fn for every action starting with prefix; fn receives the rest
+// of the action. Exact handlers win over prefixed ones.
+func (r *InputRecorder) HandlePrefix(prefix string, fn func(arg string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefixed[prefix] = fn
+}
+
+func (r *InputRecorder) lookup(action string) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if fn := r.handlers[action]; fn != nil {
+		return fn
+	}
+	for prefix, fn := range r.prefixed {
+		if strings.HasPrefix(action, prefix) {
+			arg, fn := strings.TrimPrefix(action, prefix), fn
+			return func() { fn(arg) }
+		}
+	}
+	return nil
+}
+
+// Handle registers fn as the replay handler for action without wrapping it, for inputs
+// that are recorded elsewhere (e.g. a dialog's OnClosed).
+func (r *InputRecorder) Handle(action string, fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[action] = fn
+}
+
+// Bind registers fn as the handler for action and returns a wrapper that records the
+// action before running it; use the wrapper as the widget callback.
+func (r *InputRecorder) Bind(action string, fn func()) func() {
+	r.Handle(action, fn)
+
+	return func() {
+		r.Record(action)
+		fn()
+	}
+}
+
+func (r *InputRecorder) Record(action string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.out == nil {
+		return
+	}
+	line, _ := json.Marshal(InputEvent{At: time.Since(r.start).Milliseconds(), Action: action})
+	if _, err := r.out.Write(append(line, '\n')); err != nil {
+		slog.Error("input recording failed", "err", err)
+	}
+}
+
+func (r *InputRecorder) StartRecording(path string, seed int64, stamp engine.Stamp) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	header, _ := json.Marshal(InputLog{Seed: seed, Stamp: stamp})
+	if _, err := f.Write(append(header, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	r.mu.Lock()
+	r.out = f
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *InputRecorder) StopRecording() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.out != nil {
+		_ = r.out.Close()
+		r.out = nil
+	}
+}
+
+func LoadInputLog(path string) (*InputLog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	log := &InputLog{}
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("%s: missing header", path)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), log); err != nil {
+		return nil, fmt.Errorf("%s: bad header: %w", path, err)
+	}
+	for scanner.Scan() {
+		var ev InputEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return nil, fmt.Errorf("%s: bad event: %w", path, err)
+		}
+		log.Events = append(log.Events, ev)
+	}
+	return log, scanner.Err()
+}
+
+// Replay fires each recorded action at its original offset on the UI thread.
+func (r *InputRecorder) Replay(log *InputLog) {
+	go func() {
+		for _, ev := range log.Events {
+			if wait := time.Duration(ev.At)*time.Millisecond - time.Since(r.start); wait > 0 {
+				select {
+				case <-shutdown.Done():
+					return
+				case <-time.After(wait):
+				}
+			}
+			action := ev.Action
+			fyne.Do(func() {
+				fn := r.lookup(action)
+				if fn == nil {
+					slog.Warn("replay: no handler", "action", action)
+					return
+				}
+				fn()
+			})
+		}
+	}()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== JOURNAL =====
+
+// showJournal opens the journal with the clock paused. Notes are recorded as
+// "note:<text>" so a replay reproduces them.
+func showJournal(state *engine.GameState, win fyne.Window) {
+	state.Stats.Clock.Pause()
+
+	var lines []string
+	for _, n := range state.Journal {
+		lines = append(lines, n.String())
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No notes yet.")
+	}
+	notes := newLabel(strings.Join(lines, "\n"))
+	notes.Wrapping = fyne.TextWrapWord
+
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("e.g. Fox is a red herring")
+
+	var d dialog.Dialog
+	add := func() {
+		text := strings.TrimSpace(entry.Text)
+		if text == "" {
+			return
+		}
+		inputs.Record("note:" + text)
+		engine.AddJournalNote(state, text)
+		d.Hide()
+	}
+	entry.OnSubmitted = func(string) { add() }
+
+	scroll := container.NewVScroll(notes)
+	scroll.SetMinSize(fyne.NewSize(dialogTextWidth, 160))
+	content := container.NewBorder(nil, container.NewBorder(nil, nil, nil, newButton("Add", add), entry), nil, nil, scroll)
+
+	d = dialog.NewCustom("📓 Journal", "Close", content, win)
+	d.SetOnClosed(func() {
+		inputs.Record("dismiss")
+		state.Stats.Clock.Resume()
+	})
+	inputs.Handle("dismiss", d.Hide)
+	d.Show()
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"yellowstone_evolution/engine"
+)
+
+// ===== HOST LINEAGE =====
+
+// lineageLabel shows the whole lineage on the end screens.
+func lineageLabel(state *engine.GameState) fyne.CanvasObject {
+	l := newLabel("🧬 Lineage: " + engine.LineageText(state))
+	l.Alignment = fyne.TextAlignCenter
+	l.Wrapping = fyne.TextWrapWord
+	return l
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== MAP LINT =====
+//
+// "rawr lint map.json" runs every check a map author needs before shipping a map,
+// without opening a window: the same validation as loading a custom map, reachability of
+// the apex, missing assets, red herrings without facts, and a bot-played difficulty
+// estimate. Errors make it exit 1; warnings are only reported.
+
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+	LintInfo    LintSeverity = "info"
+)
+
+// LintFinding is one line of the lint report.
+type LintFinding struct {
+	Severity LintSeverity `json:"severity"`
+	Check    string       `json:"check"`
+	Message  string       `json:"message"`
+}
+
+type LintReport struct {
+	Map      string        `json:"map"`
+	Findings []LintFinding `json:"findings"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+}
+
+func (r *LintReport) add(sev LintSeverity, check, format string, args ...any) {
+	r.Findings = append(r.Findings, LintFinding{sev, check, fmt.Sprintf(format, args...)})
+	switch sev {
+	case LintError:
+		r.Errors++
+	case LintWarning:
+		r.Warnings++
+	}
+}
+
+// lintMap checks the map at mapPath, with assets ("" for the bundled ones) as a custom
+// map would be loaded.
+func lintMap(mapPath, assets string) LintReport {
+	report := LintReport{Map: mapPath}
+
+	if err := validateMap(mapPath); err != nil {
+		report.add(LintError, "validate", "%v", err)
+		// Without a playable map the remaining checks only repeat the problem.
+		return report
+	}
+	state := engine.NewGameState(mapPath)
+	useAssets(state, assets)
+
+	an := analyzeMap(state)
+	if !an.Reachable {
+		report.add(LintError, "reachability", "no route reaches level %d", an.MaxLevel)
+	} else {
+		report.add(LintInfo, "reachability", "best route: %s", strings.Join(an.BestRoute, " → "))
+		if an.BestPlan != nil {
+			for _, w := range an.BestPlan.Warnings {
+				report.add(LintWarning, "reachability", "%s", w)
+			}
+		}
+	}
+	for _, e := range an.Endings {
+		report.add(LintInfo, "endings", "%s: %s, expected score %d", e.Plan.Ending, strings.Join(e.Route, " → "), e.Plan.ExpectedScore)
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		if a := state.Animals[name]; a.Ending != nil && (a.Level != state.MaxLevel || a.RedHerring) {
+			report.add(LintWarning, "endings", "%s has an ending but is not an apex, so it is never told", name)
+		}
+	}
+	for _, lvl := range sortedLevels(an.Hosts) {
+		if lvl > engine.StarterLevel(state) && an.Hosts[lvl] == 1 {
+			report.add(LintWarning, "reachability", "level %d has a single host, so every run goes through it", lvl)
+		}
+	}
+
+	for _, m := range missingAssets(state) {
+		report.add(LintError, "assets", "%s %s missing: %s", m.Animal, m.Kind, m.Path)
+	}
+
+	var unknown []string
+	for name := range state.RedFacts {
+		if _, ok := state.Animals[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range engine.SortedAnimalNames(state) {
+		if !state.Animals[name].RedHerring {
+			continue
+		}
+		info, ok := state.RedFacts[name]
+		switch {
+		case !ok:
+			report.add(LintError, "facts", "red herring %s has no fun fact or reason", name)
+		case info.FunFact == "" || info.Reason == "":
+			report.add(LintWarning, "facts", "red herring %s is missing its fun fact or reason", name)
+		}
+	}
+	for _, name := range unknown {
+		report.add(LintWarning, "facts", "facts are given for %s, which is not on the map", name)
+	}
+
+	e := estimateRunLength(mapPath, engine.RulesConfig{})
+	report.add(LintInfo, "difficulty", "the bot won %.0f%% of %d runs in %d–%d attempts (median %d)", e.WinRate*100, estimateRuns, e.Low, e.High, e.Median)
+	if e.WinRate == 0 {
+		report.add(LintWarning, "difficulty", "the bot never won; check that the route is not blocked by seasons or activity periods")
+	}
+	return report
+}
+
+// runLint implements the "lint" subcommand:
+//
+//	lint [--assets dir] [--json] <map.json>
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	assets := fs.String("assets", "", "folder of the map's images and red herring facts (default: the bundled ones)")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: lint [flags] <map.json>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	initDataDirs()
+
+	report := lintMap(fs.Arg(0), *assets)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		for _, f := range report.Findings {
+			fmt.Printf("%-8s %-13s %s\n", f.Severity, f.Check, f.Message)
+		}
+		fmt.Printf("%s: %d error(s), %d warning(s)\n", report.Map, report.Errors, report.Warnings)
+	}
+	if report.Errors > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"errors"
+)
+
+// ===== LITE MODE =====
+//
+// --lite is for old hardware and remote desktops. The screens are the same, but nothing
+// is decoded or animated: animal cards are text only, the background is a flat fill,
+// motion is always reduced and audio is never opened. Captions still describe sounds.
+// It is set once before the window opens and never changes, so it is read without a lock.
+
+var liteMode bool
+
+var errLiteMode = errors.New("images are off in lite mode")
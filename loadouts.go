@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== LOADOUTS =====
+
+// Loadout is a named set of run options, saved in the profile so a challenge can be
+// repeated exactly.
+type Loadout struct {
+	Name  string             `json:"name"`
+	Rules engine.RulesConfig `json:"rules"`
+}
+
+func loadoutsPath() string {
+	return filepath.Join(dataDirs.Profile, "loadouts.json")
+}
+
+// LoadLoadouts reads the saved loadouts, sorted by name; a missing file means none.
+func LoadLoadouts() []Loadout {
+	data, err := os.ReadFile(loadoutsPath())
+	if err != nil {
+		return nil
+	}
+	var out []Loadout
+	if err := json.Unmarshal(data, &out); err != nil {
+		slog.Warn("loadouts unreadable", "err", err)
+		return nil
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// SaveLoadout adds l to the profile, replacing any loadout with the same name.
+func SaveLoadout(l Loadout) error {
+	if dataDirs.Profile == "" {
+		return fmt.Errorf("no profile directory")
+	}
+	all := []Loadout{l}
+	for _, existing := range LoadLoadouts() {
+		if existing.Name != l.Name {
+			all = append(all, existing)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(loadoutsPath(), data, 0o644)
+}
+
+func findLoadout(name string) (Loadout, bool) {
+	for _, l := range LoadLoadouts() {
+		if strings.EqualFold(l.Name, name) {
+			return l, true
+		}
+	}
+	return Loadout{}, false
+}
+
+// loadoutControls lets the pre-run screen apply a saved loadout or save the current
+// options as one. Applying redraws the screen so every option reflects the loadout.
+func loadoutControls(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	loadouts := LoadLoadouts()
+	var names []string
+	for _, l := range loadouts {
+		names = append(names, l.Name)
+	}
+
+	pick := widget.NewSelect(names, nil)
+	pick.PlaceHolder = "Saved loadouts"
+	pick.OnChanged = func(name string) {
+		inputs.Record("loadout:" + name)
+		for _, l := range loadouts {
+			if l.Name == name {
+				state.Rules = l.Rules
+			}
+		}
+		win.SetContent(createIntroScreen(app, win, state))
+	}
+	for _, n := range names {
+		name := n
+		inputs.Handle("loadout:"+name, func() { pick.SetSelected(name) })
+	}
+
+	name := widget.NewEntry()
+	name.SetPlaceHolder("Loadout name")
+	save := newButton("Save", func() {
+		n := strings.TrimSpace(name.Text)
+		if n == "" {
+			return
+		}
+		if err := SaveLoadout(Loadout{Name: n, Rules: state.Rules}); err != nil {
+			showInformation(state, "Could Not Save Loadout", err.Error(), win)
+			return
+		}
+		win.SetContent(createIntroScreen(app, win, state))
+	})
+
+	return container.NewHBox(pick, container.NewGridWrap(fyne.NewSize(180, name.MinSize().Height), name), save)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"image/color"
+	"io/ioutil"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ===== LOCALE & THEME =====
+
+const themeConfigPath = "theme.json"
+
+// dialogTextWidth gives wrapped dialog text room to breathe; without a width a
+// wrapping label collapses to a single column.
+const dialogTextWidth = 420
+
+// ThemeConfig is read from theme.json in the config directory, falling back to the
+// copy next to the binary. Fonts maps a full locale ("zh-TW") or a bare language
+// ("ja", "ar") to a TTF file used for all UI text.
+type ThemeConfig struct {
+	Fonts map[string]string `json:"Fonts"`
+}
+
+func LoadThemeConfig(path string) ThemeConfig {
+	var cfg ThemeConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		slog.Warn("theme config unreadable", "err", err)
+	}
+	return cfg
+}
+
+// activeLocale decides text direction, wrapping and font; set once at startup.
+var activeLocale = lang.SystemLocale()
+
+func baseLanguage(l fyne.Locale) string {
+	base, _, _ := strings.Cut(strings.ToLower(l.String()), "-")
+	return base
+}
+
+func isRTL(l fyne.Locale) bool {
+	switch baseLanguage(l) {
+	case "ar", "he", "fa", "ur", "yi", "ps":
+		return true
+	}
+	return false
+}
+
+// isCJK covers scripts written without spaces, where word wrapping never finds a break.
+func isCJK(l fyne.Locale) bool {
+	switch baseLanguage(l) {
+	case "zh", "ja", "ko":
+		return true
+	}
+	return false
+}
+
+func textWrapFor(l fyne.Locale) fyne.TextWrap {
+	if isCJK(l) {
+		return fyne.TextWrapBreak
+	}
+	return fyne.TextWrapWord
+}
+
+// newLocalizedText is a wrapping label laid out for the active locale: leading-aligned
+// text flips to the right for RTL languages and CJK text may break between any glyphs.
+func newLocalizedText(text string, align fyne.TextAlign) fyne.CanvasObject {
+	label := widget.NewLabel(plain(text))
+	label.Wrapping = textWrapFor(activeLocale)
+	if align == fyne.TextAlignLeading && isRTL(activeLocale) {
+		align = fyne.TextAlignTrailing
+	}
+	label.Alignment = align
+
+	width := canvas.NewRectangle(color.Transparent)
+	width.SetMinSize(fyne.NewSize(dialogTextWidth, 0))
+	return container.NewVBox(width, label)
+}
+
+// localeTheme swaps in a per-locale font from ThemeConfig and otherwise defers to the
+// default theme.
+type localeTheme struct {
+	fyne.Theme
+	font fyne.Resource
+}
+
+func newLocaleTheme(cfg ThemeConfig, l fyne.Locale) fyne.Theme {
+	t := &localeTheme{Theme: theme.DefaultTheme()}
+
+	path, ok := cfg.Fonts[l.String()]
+	if !ok {
+		path, ok = cfg.Fonts[l.LanguageString()]
+	}
+	if !ok {
+		path, ok = cfg.Fonts[baseLanguage(l)]
+	}
+	if ok {
+		font, err := fyne.LoadResourceFromPath(path)
+		if err != nil {
+			slog.Warn("theme font unreadable", "err", err)
+		} else {
+			t.font = font
+		}
+	}
+	return t
+}
+
+func (t *localeTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if t.font != nil && !style.Monospace && !style.Symbol {
+		return t.font
+	}
+	return t.Theme.Font(style)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// ===== LOGGING =====
+//
+// Diagnostics go through slog: to stderr, and to logs/rawr.log in the config directory
+// so players can attach the file to a bug report. The file is rotated when it grows past
+// logMaxSize, keeping logKeep old copies as rawr.1.log, rawr.2.log and so on.
+//
+// Command output (simulate summaries, usage, JSON results) is not logging and still
+// goes straight to stdout or stderr.
+
+const (
+	logDirName = "logs"
+	logName    = "rawr"
+	logMaxSize = 1 << 20
+	logKeep    = 3
+)
+
+// logFile is the open log file, or nil when logging only to stderr.
+var logFile *RotatingFile
+
+func logDir() string {
+	if dataDirs.Config == "" {
+		return ""
+	}
+	return filepath.Join(dataDirs.Config, logDirName)
+}
+
+func logPath(n int) string {
+	if n == 0 {
+		return filepath.Join(logDir(), logName+".log")
+	}
+	return filepath.Join(logDir(), fmt.Sprintf("%s.%d.log", logName, n))
+}
+
+// RotatingFile appends to the current log file and rotates it once it passes logMaxSize.
+type RotatingFile struct {
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func openRotatingFile() (*RotatingFile, error) {
+	if err := os.MkdirAll(logDir(), 0o755); err != nil {
+		return nil, err
+	}
+	r := &RotatingFile{}
+	return r, r.open()
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(logPath(0), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts rawr.log to rawr.1.log, rawr.1.log to rawr.2.log and so on, dropping the
+// oldest, and starts a new rawr.log.
+func (r *RotatingFile) rotate() error {
+	r.f.Close()
+	for n := logKeep - 1; n >= 0; n-- {
+		if err := os.Rename(logPath(n), logPath(n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > logMaxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
+
+// logFlags registers the logging flags on fs and returns a function that installs the
+// logger once fs has been parsed and the data directories are known.
+func logFlags(fs *flag.FlagSet) func() {
+	level := fs.String("log-level", "info", "lowest level to log: debug, info, warn or error")
+	asJSON := fs.Bool("log-json", false, "log as JSON lines instead of text")
+
+	return func() {
+		var lvl slog.Level
+		if err := lvl.UnmarshalText([]byte(*level)); err != nil {
+			fmt.Fprintln(os.Stderr, "Unknown log level", *level, "- using info")
+			lvl = slog.LevelInfo
+		}
+
+		var w io.Writer = os.Stderr
+		if logDir() != "" {
+			file, err := openRotatingFile()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Log file error:", err)
+			} else {
+				logFile = file
+				w = io.MultiWriter(os.Stderr, file)
+				shutdown.OnShutdown("log file", func() { _ = file.Close() })
+			}
+		}
+
+		opts := &slog.HandlerOptions{Level: lvl}
+		var h slog.Handler = slog.NewTextHandler(w, opts)
+		if *asJSON {
+			h = slog.NewJSONHandler(w, opts)
+		}
+		slog.SetDefault(slog.New(h))
+	}
+}
+
+// saveLogFile lets the player save a copy of the current log, e.g. for a bug report.
+func saveLogFile(win fyne.Window) {
+	if logFile == nil {
+		dialog.ShowError(fmt.Errorf("no log file is being written"), win)
+		return
+	}
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if w == nil {
+			return
+		}
+		defer w.Close()
+		in, err := os.Open(logPath(0))
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		defer in.Close()
+		if _, err := io.Copy(w, in); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	save.SetFileName(logName + ".log")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".log"}))
+	save.Show()
+}
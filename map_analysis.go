@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"sort"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== MAP ANALYSIS =====
+
+// MapAnalysis summarizes how playable a map is.
+type MapAnalysis struct {
+	MaxLevel int `json:"max_level"`
+	// Hosts counts the infectable (non-red-herring) animals per level.
+	Hosts     map[int]int `json:"hosts"`
+	Reachable bool        `json:"reachable"`
+	// BestRoute greedily takes the likeliest host at each level; its plan is the
+	// difficulty estimate.
+	BestRoute []string   `json:"best_route,omitempty"`
+	BestPlan  *RoutePlan `json:"best_plan,omitempty"`
+	// Endings plans the same climb to each apex in turn, when there is more than one.
+	Endings []EndingRoute `json:"endings,omitempty"`
+}
+
+// EndingRoute is the best route to one apex.
+type EndingRoute struct {
+	Apex  string    `json:"apex"`
+	Route []string  `json:"route"`
+	Plan  RoutePlan `json:"plan"`
+}
+
+func analyzeMap(state *engine.GameState) MapAnalysis {
+	an := MapAnalysis{MaxLevel: state.MaxLevel, Hosts: map[int]int{}}
+
+	best := map[int]*engine.Animal{}
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.RedHerring || a.Juvenile {
+			continue
+		}
+		an.Hosts[a.Level]++
+		if cur, ok := best[a.Level]; !ok || routeCost(state, a) < routeCost(state, cur) {
+			best[a.Level] = a
+		}
+	}
+
+	an.Reachable = state.MaxLevel > 0
+	var route []*engine.Animal
+	for lvl := engine.StarterLevel(state); lvl <= state.MaxLevel; lvl++ {
+		a, ok := best[lvl]
+		if !ok {
+			an.Reachable = false
+			break
+		}
+		route = append(route, a)
+		an.BestRoute = append(an.BestRoute, a.Name)
+	}
+
+	if an.Reachable {
+		if plan, err := PlanRoute(state, route); err == nil {
+			an.BestPlan = &plan
+		}
+	}
+	if apex := engine.Apexes(state); an.Reachable && len(apex) > 1 {
+		below := route[:len(route)-1]
+		for _, a := range apex {
+			r := append(slices.Clone(below), a)
+			plan, err := PlanRoute(state, r)
+			if err != nil {
+				continue
+			}
+			e := EndingRoute{Apex: a.Name, Plan: plan}
+			for _, h := range r {
+				e.Route = append(e.Route, h.Name)
+			}
+			an.Endings = append(an.Endings, e)
+		}
+	}
+	return an
+}
+
+// routeCost is the expected number of rolls needed to take a as a host.
+func routeCost(state *engine.GameState, a *engine.Animal) float64 {
+	p := approxChance(state, a)
+	if p <= 0 {
+		return 1e9
+	}
+	return float64(1+len(a.ResistancePhases)) / p
+}
+
+func sortedLevels(m map[int]int) []int {
+	var levels []int
+	for l := range m {
+		levels = append(levels, l)
+	}
+	sort.Ints(levels)
+	return levels
+}
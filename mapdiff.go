@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== MAP DIFF =====
+
+// runMapDiff implements the "mapdiff" subcommand:
+//
+//	mapdiff old.json new.json
+func runMapDiff(args []string) int {
+	fs := flag.NewFlagSet("mapdiff", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: mapdiff <old.json> <new.json>")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	oldState, newState := engine.NewGameState(fs.Arg(0)), engine.NewGameState(fs.Arg(1))
+	for _, s := range []*engine.GameState{oldState, newState} {
+		if len(s.Animals) == 0 {
+			fmt.Fprintln(os.Stderr, "Mapdiff error: no animals loaded from", s.MapPath)
+			return 1
+		}
+	}
+
+	printMapDiff(os.Stdout, oldState, newState)
+	return 0
+}
+
+func printMapDiff(w io.Writer, oldState, newState *engine.GameState) {
+	for _, name := range engine.SortedAnimalNames(newState) {
+		if _, ok := oldState.Animals[name]; !ok {
+			a := newState.Animals[name]
+			fmt.Fprintf(w, "+ %s (level %d, %.0f%%%s)\n", name, a.Level, a.InfectionRate*100, herringTag(a))
+		}
+	}
+	for _, name := range engine.SortedAnimalNames(oldState) {
+		if _, ok := newState.Animals[name]; !ok {
+			fmt.Fprintf(w, "- %s\n", name)
+		}
+	}
+
+	for _, name := range engine.SortedAnimalNames(newState) {
+		o, ok := oldState.Animals[name]
+		if !ok {
+			continue
+		}
+		if changes := animalChanges(o, newState.Animals[name]); len(changes) > 0 {
+			fmt.Fprintf(w, "~ %s\n", name)
+			for _, c := range changes {
+				fmt.Fprintf(w, "    %s\n", c)
+			}
+		}
+	}
+
+	before, after := analyzeMap(oldState), analyzeMap(newState)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Max level:   %d → %d\n", before.MaxLevel, after.MaxLevel)
+	fmt.Fprintf(w, "Reachable:   %t → %t\n", before.Reachable, after.Reachable)
+
+	levels := map[int]int{}
+	for l := range before.Hosts {
+		levels[l] = 0
+	}
+	for l := range after.Hosts {
+		levels[l] = 0
+	}
+	for _, l := range sortedLevels(levels) {
+		if before.Hosts[l] != after.Hosts[l] {
+			fmt.Fprintf(w, "Level %d hosts: %d → %d\n", l, before.Hosts[l], after.Hosts[l])
+		}
+	}
+
+	fmt.Fprintf(w, "Best route:  %s\n", strings.Join(before.BestRoute, " → "))
+	fmt.Fprintf(w, "          →  %s\n", strings.Join(after.BestRoute, " → "))
+	if before.BestPlan != nil && after.BestPlan != nil {
+		fmt.Fprintf(w, "Best-route expected attempts: %.1f → %.1f\n", before.BestPlan.ExpectedAttempts, after.BestPlan.ExpectedAttempts)
+		fmt.Fprintf(w, "Best-route expected score:    %d → %d\n", before.BestPlan.ExpectedScore, after.BestPlan.ExpectedScore)
+	}
+}
+
+func herringTag(a *engine.Animal) string {
+	if a.RedHerring {
+		return ", red herring"
+	}
+	return ""
+}
+
+func animalChanges(o, n *engine.Animal) []string {
+	var out []string
+	if o.Level != n.Level {
+		out = append(out, fmt.Sprintf("Level: %d → %d", o.Level, n.Level))
+	}
+	if o.InfectionRate != n.InfectionRate {
+		out = append(out, fmt.Sprintf("InfectionRate: %.2f → %.2f", o.InfectionRate, n.InfectionRate))
+	}
+	if o.Intelligence != n.Intelligence {
+		out = append(out, fmt.Sprintf("Intelligence: %d → %d", o.Intelligence, n.Intelligence))
+	}
+	if o.Mobility != n.Mobility {
+		out = append(out, fmt.Sprintf("Mobility: %s → %s", o.Mobility, n.Mobility))
+	}
+	if o.Location != n.Location {
+		out = append(out, fmt.Sprintf("Location: %s → %s", o.Location, n.Location))
+	}
+	if o.ActivityPeriod != n.ActivityPeriod {
+		out = append(out, fmt.Sprintf("ActivityPeriod: %q → %q", o.ActivityPeriod, n.ActivityPeriod))
+	}
+	if o.RedHerring != n.RedHerring {
+		out = append(out, fmt.Sprintf("RedHerring: %t → %t", o.RedHerring, n.RedHerring))
+	}
+	if len(o.ResistancePhases) != len(n.ResistancePhases) {
+		out = append(out, fmt.Sprintf("ResistancePhases: %d → %d", len(o.ResistancePhases), len(n.ResistancePhases)))
+	}
+	if oe, ne := engine.EndingOf(o), engine.EndingOf(n); oe != ne {
+		out = append(out, fmt.Sprintf("Ending: %q %+d → %q %+d", oe.Title, oe.ScoreBonus, ne.Title, ne.ScoreBonus))
+	}
+
+	added, removed := diffStrings(o.Contacts.Names(), n.Contacts.Names())
+	for _, c := range added {
+		out = append(out, "Contacts: + "+c)
+	}
+	for _, c := range removed {
+		out = append(out, "Contacts: - "+c)
+	}
+	for _, c := range n.Contacts {
+		if w, ok := o.Contacts.Weight(c.Name); ok && w != c.Weight {
+			out = append(out, fmt.Sprintf("Contacts: %s weight %.2f → %.2f", c.Name, w, c.Weight))
+		}
+		if k, ok := o.Contacts.Kind(c.Name); ok && k != c.Kind {
+			out = append(out, fmt.Sprintf("Contacts: %s kind %q → %q", c.Name, k, c.Kind))
+		}
+	}
+	return out
+}
+
+// diffStrings returns what b adds to and removes from a.
+func diffStrings(a, b []string) (added, removed []string) {
+	inA, inB := map[string]bool{}, map[string]bool{}
+	for _, s := range a {
+		inA[s] = true
+	}
+	for _, s := range b {
+		inB[s] = true
+		if !inA[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
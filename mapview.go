@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== PARK MAP VIEW =====
+//
+// The third board view lays the park out by Location: every place an animal lives is a
+// region on the map, tinted from green to virus red by the share of its animals that
+// are infected, with small portraits of who lives there. Tapping a region opens it
+// below the map as ordinary cards, INFECT buttons and all, so the map is another way
+// to pick a target rather than a separate game.
+//
+// The bundled park's places are arranged roughly as they lie, ridges to the north and
+// water to the south; places a custom map adds follow them, in name order.
+
+const (
+	prefMapView = "boardMapView"
+
+	mapColumns      = 4
+	regionPortraits = 32
+)
+
+// parkLayout is where the bundled park's places go on the map, row by row.
+var parkLayout = []string{
+	"Ridge", "RockySlope", "Forest", "ForestFloor",
+	"Outpost", "Valley", "Meadow", "ForestEdge",
+	"Marsh", "River", "Riverbank", "Grassland",
+}
+
+// mapRegion is the region opened below the map; "" when none is.
+var mapRegion string
+
+// Region is one place on the park map.
+type Region struct {
+	Location string
+	Animals  []*engine.Animal
+	Infected int
+	Immune   int
+	// Host is set when patient zero's current host lives here.
+	Host bool
+}
+
+// InfectedShare is the part of the region's animals that carry the virus.
+func (r Region) InfectedShare() float64 {
+	if len(r.Animals) == 0 {
+		return 0
+	}
+	return float64(r.Infected) / float64(len(r.Animals))
+}
+
+// parkRegions groups every animal by Location, in map order; animals without a
+// Location are left off the map.
+func parkRegions(state *engine.GameState) []Region {
+	byLoc := map[string]*Region{}
+	var extra []string
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.Location == "" {
+			continue
+		}
+		r, ok := byLoc[a.Location]
+		if !ok {
+			r = &Region{Location: a.Location}
+			byLoc[a.Location] = r
+			if !slices.Contains(parkLayout, a.Location) {
+				extra = append(extra, a.Location)
+			}
+		}
+		r.Animals = append(r.Animals, a)
+		if a.Infected {
+			r.Infected++
+		}
+		if engine.IsImmune(state, a) {
+			r.Immune++
+		}
+		if name == state.PlayerName {
+			r.Host = true
+		}
+	}
+
+	slices.Sort(extra)
+	var out []Region
+	for _, loc := range append(slices.Clone(parkLayout), extra...) {
+		if r, ok := byLoc[loc]; ok {
+			out = append(out, *r)
+		}
+	}
+	return out
+}
+
+// regionColor runs from a healthy green through to virus red as share rises.
+func regionColor(share float64) color.NRGBA {
+	mix := func(from, to uint8) uint8 { return uint8(float64(from) + (float64(to)-float64(from))*share) }
+	return color.NRGBA{R: mix(0x3a, 0xc8), G: mix(0x6a, 0x28), B: mix(0x3a, 0x38), A: 0xb0}
+}
+
+// mapViewToggle switches the board to and from the park map; the choice is
+// remembered in the app preferences.
+func mapViewToggle(app fyne.App, redraw func()) *widget.Button {
+	prefs := app.Preferences()
+	mapView := prefs.Bool(prefMapView)
+	label := "🗺 Map view"
+	if mapView {
+		label = "▦ Board view"
+	}
+	return newButton(label, func() {
+		prefs.SetBool(prefMapView, !mapView)
+		redraw()
+	})
+}
+
+// parkMap draws the regions and, below them, the open region's targets as cards.
+// targets and cards are the candidate targets and their cards, in the same order.
+func parkMap(state *engine.GameState, targets []*engine.Animal, cards []boardCard, columns int, redraw func()) fyne.CanvasObject {
+	regions := parkRegions(state)
+	if !slices.ContainsFunc(regions, func(r Region) bool { return r.Location == mapRegion }) {
+		mapRegion = ""
+	}
+
+	tiles := make([]fyne.CanvasObject, len(regions))
+	for i, r := range regions {
+		tiles[i] = regionTile(state, r, targets, redraw)
+	}
+	board := container.NewVBox(container.NewGridWithColumns(mapColumns, tiles...))
+	if mapRegion == "" {
+		board.Add(container.NewCenter(newLabel("Tap a region to see who lives there.")))
+		return board
+	}
+
+	var open []boardCard
+	var others []string
+	for _, a := range regionOf(regions, mapRegion).Animals {
+		if i := slices.Index(targets, a); i >= 0 {
+			open = append(open, cards[i])
+			continue
+		}
+		others = append(others, a.Name+" ("+regionStatus(state, a)+")")
+	}
+	board.Add(widget.NewLabelWithStyle(plain("📍 "+mapRegion), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	if len(open) == 0 {
+		board.Add(container.NewCenter(newLabel("No animal here can be targeted today.")))
+	} else {
+		board.Add(adaptiveBoard(columns, open))
+	}
+	if len(others) > 0 {
+		also := newLabel("Also here: " + strings.Join(others, ", "))
+		also.Alignment = fyne.TextAlignCenter
+		also.Wrapping = fyne.TextWrapWord
+		board.Add(also)
+	}
+	return board
+}
+
+func regionOf(regions []Region, loc string) Region {
+	for _, r := range regions {
+		if r.Location == loc {
+			return r
+		}
+	}
+	return Region{}
+}
+
+// regionTile is one region on the map: its tint, name, tally, portraits and a button
+// to open it. Portraits of animals that are not targets today are faded.
+func regionTile(state *engine.GameState, r Region, targets []*engine.Animal, redraw func()) fyne.CanvasObject {
+	bg := canvas.NewRectangle(regionColor(r.InfectedShare()))
+	bg.CornerRadius = 8
+	if r.Location == mapRegion {
+		bg.StrokeColor = color.White
+		bg.StrokeWidth = 3
+	}
+
+	name := r.Location
+	if r.Host {
+		name = "🦠 " + name
+	}
+	tally := fmt.Sprintf("%d/%d infected", r.Infected, len(r.Animals))
+	if r.Immune > 0 {
+		tally += fmt.Sprintf(" · 🩹 %d", r.Immune)
+	}
+
+	portraits := container.NewHBox()
+	for _, a := range r.Animals {
+		img := loadAnimalImage(a.GetImagePath(), regionPortraits, statusFilters(state, a)...)
+		if !slices.Contains(targets, a) {
+			img.Translucency = 0.6
+		}
+		portraits.Add(img)
+	}
+
+	open := newButton(fmt.Sprintf("🔍 %d here", len(r.Animals)), inputs.Bind("region:"+r.Location, func() {
+		mapRegion = r.Location
+		redraw()
+	}))
+	body := container.NewVBox(
+		widget.NewLabelWithStyle(plain(name), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		container.NewCenter(newLabel(tally)),
+		container.NewCenter(portraits),
+		container.NewCenter(open),
+	)
+	return container.NewStack(bg, container.NewPadded(body))
+}
+
+// regionStatus says why an animal on the map is not a target, e.g. "infected".
+func regionStatus(state *engine.GameState, a *engine.Animal) string {
+	switch {
+	case a.Name == state.PlayerName:
+		return "host"
+	case a.Infected:
+		return "infected"
+	case engine.IsImmune(state, a):
+		return "immune"
+	}
+	if reason := engine.BlockedReason(state, a); reason != "" {
+		return reason
+	}
+	return "out of reach"
+}
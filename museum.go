@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== GENEALOGY MUSEUM =====
+
+// MuseumEntry is the transmission tree of one winning run.
+type MuseumEntry struct {
+	Date    time.Time             `json:"date"`
+	Seed    int64                 `json:"seed"`
+	Score   int                   `json:"score"`
+	Stamp   engine.Stamp          `json:"stamp"`
+	Rules   engine.RulesConfig    `json:"rules"`
+	NGPlus  int                   `json:"ng_plus,omitempty"`
+	Starter string                `json:"starter"`
+	Tree    []engine.Transmission `json:"tree"`
+	Journal []engine.JournalNote  `json:"journal,omitempty"`
+	RunID   int64                 `json:"run_id,omitempty"`
+	Tags    []string              `json:"tags,omitempty"`
+
+	// Signature is set by signEntry; Tampered is set on load when it does not verify.
+	Signature string `json:"signature,omitempty"`
+	Tampered  bool   `json:"-"`
+}
+
+func (e MuseumEntry) Label() string {
+	label := fmt.Sprintf("%s — %s — score %d — seed %d", e.Date.Format("2006-01-02 15:04"), e.Starter, e.Score, e.Seed)
+	if len(e.Tags) > 0 {
+		label += " — 🏷 " + strings.Join(e.Tags, ", ")
+	}
+	switch {
+	case e.Signature == "":
+		label += " — unsigned"
+	case e.Tampered:
+		label += " — ⚠ edited"
+	}
+	return label
+}
+
+func museumPath() string {
+	return filepath.Join(dataDirs.Profile, "museum.json")
+}
+
+// LoadMuseum returns the saved trees, newest first.
+func LoadMuseum() []MuseumEntry {
+	data, err := os.ReadFile(museumPath())
+	if err != nil {
+		return nil
+	}
+	var out []MuseumEntry
+	if err := json.Unmarshal(data, &out); err != nil {
+		slog.Error("museum unreadable", "err", err)
+		return nil
+	}
+	for i := range out {
+		out[i].Tampered = out[i].Signature != "" && !verifyEntry(out[i])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.After(out[j].Date) })
+	return out
+}
+
+// recordInMuseum saves the finished run's transmission tree to the profile.
+func recordInMuseum(state *engine.GameState) error {
+	if dataDirs.Profile == "" {
+		return fmt.Errorf("no profile directory")
+	}
+	entry := MuseumEntry{
+		Date:    time.Now(),
+		Seed:    state.Seed,
+		Score:   engine.CalculateScore(state),
+		Stamp:   engine.CurrentStamp(state.MapPath),
+		Rules:   state.Rules,
+		NGPlus:  state.NGPlus,
+		Starter: state.Starter,
+		Tree:    state.Transmissions,
+		Journal: state.Journal,
+		RunID:   state.RunID,
+	}
+	sig, err := signEntry(entry)
+	if err != nil {
+		return err
+	}
+	entry.Signature = sig
+	return saveMuseum(append(LoadMuseum(), entry))
+}
+
+func saveMuseum(entries []MuseumEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(museumPath(), data, 0o644)
+}
+
+// tagMuseumEntry sets the tags of the entry for runID, if there is one. Only an entry
+// that still verifies is signed again, so tagging never clears the edited flag.
+func tagMuseumEntry(runID int64, tags []string) error {
+	entries := LoadMuseum()
+	for i := range entries {
+		e := &entries[i]
+		if e.RunID != runID {
+			continue
+		}
+		e.Tags = tags
+		if e.Signature != "" && !e.Tampered {
+			sig, err := signEntry(*e)
+			if err != nil {
+				return err
+			}
+			e.Signature = sig
+		}
+		return saveMuseum(entries)
+	}
+	return nil
+}
+
+// treeLines draws a transmission tree as indented text. mark, if set, annotates each
+// animal (used by the compare view).
+func treeLines(starter string, tree []engine.Transmission, mark func(name string) string) []string {
+	children := map[string][]engine.Transmission{}
+	for _, t := range tree {
+		children[t.From] = append(children[t.From], t)
+	}
+
+	lines := []string{"🦠 " + starter + mark(starter)}
+	var walk func(name, indent string)
+	walk = func(name, indent string) {
+		kids := children[name]
+		for i, t := range kids {
+			branch, next := "├─ ", "│  "
+			if i == len(kids)-1 {
+				branch, next = "└─ ", "   "
+			}
+			lines = append(lines, fmt.Sprintf("%s%s%s (day %d)%s", indent, branch, t.To, t.Day, mark(t.To)))
+			walk(t.To, indent+next)
+		}
+	}
+	walk(starter, "")
+	return lines
+}
+
+// overlayTrees merges two runs into one tree, marking which run each animal fell in.
+func overlayTrees(a, b MuseumEntry) []string {
+	inA, inB := map[string]bool{a.Starter: true}, map[string]bool{b.Starter: true}
+	for _, t := range a.Tree {
+		inA[t.To] = true
+	}
+	for _, t := range b.Tree {
+		inB[t.To] = true
+	}
+	mark := func(name string) string {
+		switch {
+		case inA[name] && inB[name]:
+			return "  [A+B]"
+		case inA[name]:
+			return "  [A]"
+		default:
+			return "  [B]"
+		}
+	}
+
+	var lines []string
+	seen := map[string]bool{}
+	var union []engine.Transmission
+	for _, t := range append(append([]engine.Transmission{}, a.Tree...), b.Tree...) {
+		if !seen[t.From+"→"+t.To] {
+			seen[t.From+"→"+t.To] = true
+			union = append(union, t)
+		}
+	}
+	lines = append(lines, treeLines(a.Starter, union, mark)...)
+	if b.Starter != a.Starter {
+		lines = append(lines, treeLines(b.Starter, union, mark)...)
+	}
+	return lines
+}
+
+func monoText(lines []string) fyne.CanvasObject {
+	txt := widget.NewLabelWithStyle(strings.Join(lines, "\n"), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	return container.NewScroll(txt)
+}
+
+func createMuseumScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	entries := LoadMuseum()
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("🏛 Infection Genealogy Museum"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	header := container.NewBorder(nil, nil, back, nil, title)
+
+	if len(entries) == 0 {
+		return NewClickInterceptor(container.NewMax(loadBackground(),
+			container.NewBorder(header, nil, nil, nil, container.NewCenter(newLabel("No winning runs yet. Win one to start the collection.")))))
+	}
+
+	labels := make([]string, len(entries))
+	for i, e := range entries {
+		labels[i] = e.Label()
+	}
+	byLabel := func(label string) (MuseumEntry, bool) {
+		for _, e := range entries {
+			if e.Label() == label {
+				return e, true
+			}
+		}
+		return MuseumEntry{}, false
+	}
+
+	view := container.NewMax()
+	show := func(lines []string) {
+		view.Objects = []fyne.CanvasObject{monoText(lines)}
+		view.Refresh()
+	}
+
+	list := widget.NewList(
+		func() int { return len(entries) },
+		func() fyne.CanvasObject { return newLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) { o.(*widget.Label).SetText(plain(labels[i])) },
+	)
+	list.OnSelected = func(i widget.ListItemID) {
+		e := entries[i]
+		lines := []string{e.Label(), fmt.Sprintf("Map %s, ruleset %s", e.Stamp.MapHash, e.Stamp.RulesetHash), ""}
+		lines = append(lines, treeLines(e.Starter, e.Tree, func(string) string { return "" })...)
+		if len(e.Journal) > 0 {
+			lines = append(lines, "", "Journal:")
+			for _, n := range e.Journal {
+				lines = append(lines, "  "+n.String())
+			}
+		}
+		show(lines)
+	}
+
+	first, second := widget.NewSelect(labels, nil), widget.NewSelect(labels, nil)
+	first.PlaceHolder, second.PlaceHolder = "Run A", "Run B"
+	compare := newButton("Compare", func() {
+		a, okA := byLabel(first.Selected)
+		b, okB := byLabel(second.Selected)
+		if !okA || !okB {
+			return
+		}
+		lines := []string{"A: " + a.Label(), "B: " + b.Label(), ""}
+		show(append(lines, overlayTrees(a, b)...))
+	})
+
+	list.Select(0)
+	left := container.NewBorder(nil, container.NewVBox(first, second, compare), nil, nil, list)
+	split := container.NewHSplit(left, view)
+	split.Offset = 0.45
+
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, split)))
+}
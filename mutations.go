@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"yellowstone_evolution/engine"
+)
+
+// ===== MUTATION TREE =====
+
+// upgradeLine describes u and how far it has been bought, for the menus.
+func upgradeLine(state *engine.GameState, u engine.Upgrade) string {
+	return fmt.Sprintf("%s (%d/%d, %d pts): %s", u.Name, state.Upgrades[u.ID], u.MaxLevel, u.Cost, u.Description)
+}
+
+// mutationMenu lists the points and every upgrade, for batch mode's "upgrades".
+func mutationMenu(state *engine.GameState) string {
+	lines := []string{fmt.Sprintf("%d mutation point(s)", state.MutationPoints)}
+	for _, u := range engine.Upgrades {
+		lines = append(lines, u.ID+" — "+upgradeLine(state, u))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mutationControls is the header button that opens the upgrade screen.
+func mutationControls(state *engine.GameState, win fyne.Window, redraw func()) fyne.CanvasObject {
+	buy := func(id string) error {
+		u, err := engine.FindUpgrade(id)
+		if err != nil {
+			return err
+		}
+		return engine.BuyUpgrade(state, u)
+	}
+	inputs.HandlePrefix("upgrade:", func(id string) {
+		if buy(id) == nil {
+			redraw()
+		}
+	})
+
+	return newButton(fmt.Sprintf("🧪 Mutations (%d pts)", state.MutationPoints), func() {
+		state.Stats.Clock.Pause()
+		points := newLabel("")
+		list := container.NewVBox()
+		var fill func()
+		fill = func() {
+			points.SetText(fmt.Sprintf("%d mutation point(s) to spend. Virus: %s, strength %.0f%%.", state.MutationPoints, strings.Join(state.Virus.Modes, ", "), state.Virus.Strength*100))
+			list.RemoveAll()
+			for _, u := range engine.Upgrades {
+				b := newButton("Evolve", func() {
+					inputs.Record("upgrade:" + u.ID)
+					if err := buy(u.ID); err != nil {
+						dialog.ShowError(err, win)
+						return
+					}
+					fill()
+				})
+				if state.Upgrades[u.ID] >= u.MaxLevel || state.MutationPoints < u.Cost || state.Phase != engine.PhaseMorning {
+					b.Disable()
+				}
+				list.Add(container.NewBorder(nil, nil, nil, b, newLabel(upgradeLine(state, u))))
+			}
+			list.Refresh()
+		}
+		fill()
+
+		intro := newLabel(fmt.Sprintf("Each infection earns %d point(s), a level up %d. Upgrades can only be bought in the morning, before the day's first move.", engine.MutationPointsSameLevel, engine.MutationPointsLevelUp))
+		intro.Wrapping = fyne.TextWrapWord
+		d := dialog.NewCustom(plain("🧪 Mutations"), "Done", container.NewBorder(container.NewVBox(intro, points), nil, nil, nil, list), win)
+		d.Resize(fyne.NewSize(560, 360))
+		d.SetOnClosed(func() {
+			state.Stats.Clock.Resume()
+			redraw()
+		})
+		d.Show()
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== NEW GAME PLUS =====
+
+// startNewGamePlus reloads the same map one NG+ cycle harder, carrying a single virus
+// mutation over from the finished run.
+func startNewGamePlus(state *engine.GameState, carry string) *engine.GameState {
+	next := engine.NewGameState(state.MapPath)
+	next.Rules = state.Rules
+	engine.SeedRun(next, state.Seed)
+	applyNewGamePlus(next, state.NGPlus+1, carry)
+	return next
+}
+
+// applyNewGamePlus makes a fresh run the given NG+ cycle, carrying the mutation carry.
+func applyNewGamePlus(state *engine.GameState, ngPlus int, carry string) {
+	state.NGPlus = ngPlus
+	scale := math.Pow(engine.NGPlusRateFactor, float64(ngPlus))
+	for _, a := range state.Animals {
+		a.InfectionRate *= scale
+	}
+
+	if carry != "" && !state.Virus.HasMode(carry) {
+		state.Virus.Modes = append(state.Virus.Modes, carry)
+	}
+	state.CarriedMutation = carry
+}
+
+func newGamePlusControls(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	carry := widget.NewSelect(state.Virus.Modes, nil)
+	carry.PlaceHolder = "Mutation to carry"
+	if n := len(state.Virus.Modes); n > 0 {
+		carry.SetSelected(state.Virus.Modes[n-1])
+	}
+	carry.OnChanged = func(mode string) {
+		inputs.Record("carry:" + mode)
+	}
+	for _, m := range state.Virus.Modes {
+		mode := m
+		inputs.Handle("carry:"+mode, func() { carry.SetSelected(mode) })
+	}
+
+	start := newButton(fmt.Sprintf("New Game Plus %d ➜", state.NGPlus+1), inputs.Bind("newgameplus", func() {
+		next := startNewGamePlus(state, carry.Selected)
+		win.SetContent(createStarterSelectionScreen(app, win, next))
+	}))
+
+	return container.NewHBox(newLabel("Carry forward:"), carry, start)
+}
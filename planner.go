@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== ROUTE PLANNER =====
+//
+// The planner evaluates a proposed route with an approximation of the game's chances:
+// each host's base rate averaged over the seasons and scaled by the virus. Contacts,
+// moods, curses and map modifiers are left out, and it assumes the target is always
+// available when wanted, so waits for activity periods and seasons are not included in
+// the day estimate. Its numbers are estimates, not what a run will see.
+
+// planSecondsPerAttempt is the assumed real time spent per attempt, for the time penalty.
+const planSecondsPerAttempt = 5.0
+
+type PlanStep struct {
+	Animal           string  `json:"animal"`
+	Level            int     `json:"level"`
+	Chance           float64 `json:"chance"`
+	Rolls            int     `json:"rolls"`
+	ExpectedAttempts float64 `json:"expected_attempts"`
+	ExpectedDays     float64 `json:"expected_days"`
+	Note             string  `json:"note,omitempty"`
+}
+
+type RoutePlan struct {
+	Steps            []PlanStep `json:"steps"`
+	ExpectedAttempts float64    `json:"expected_attempts"`
+	ExpectedDays     float64    `json:"expected_days"`
+	// SuccessProbability is the chance the whole route succeeds with every roll first try.
+	SuccessProbability float64 `json:"success_probability"`
+	ExpectedScore      int     `json:"expected_score"`
+	// Ending is the apex the route wins on, if it reaches one, and EndingBonus what that
+	// ending adds to the score.
+	Ending      string   `json:"ending,omitempty"`
+	EndingBonus int      `json:"ending_bonus,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// approxChance is the per-roll success chance used for planning: the base rate with
+// seasonal shifts averaged out, scaled by the virus. It is not engine.InfectionChance,
+// which depends on the host, the day and everything the run has done so far.
+func approxChance(state *engine.GameState, a *engine.Animal) float64 {
+	if a.RedHerring {
+		return 0
+	}
+	total := 0.0
+	for s := engine.SeasonSpring; s <= engine.SeasonWinter; s++ {
+		total += a.SeasonalRate(s)
+	}
+	p := total / 4 * engine.VirusStrength(state)
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// PlanRoute evaluates route, which starts with patient zero and lists every host after it.
+func PlanRoute(state *engine.GameState, route []*engine.Animal) (RoutePlan, error) {
+	var plan RoutePlan
+	if len(route) == 0 {
+		return plan, fmt.Errorf("empty route")
+	}
+
+	starter := route[0]
+	if starter.RedHerring {
+		return plan, fmt.Errorf("%s is a red herring and cannot be patient zero", starter.Name)
+	}
+	if lvl := engine.StarterLevel(state); starter.Level != lvl {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s is level %d; patient zero must be level %d", starter.Name, starter.Level, lvl))
+	}
+	plan.Steps = append(plan.Steps, PlanStep{Animal: starter.Name, Level: starter.Level, Chance: 1, Note: "patient zero"})
+
+	plan.SuccessProbability = 1
+	mercy := state.Rules.MercyRule
+	nextLevel, sameLevel := 0, 0
+	infected := map[string]bool{starter.Name: true}
+
+	for i := 1; i < len(route); i++ {
+		prev, a := route[i-1], route[i]
+		step := PlanStep{Animal: a.Name, Level: a.Level, Rolls: 1 + len(a.ResistancePhases)}
+
+		switch {
+		case a.RedHerring:
+			return plan, fmt.Errorf("%s is a red herring and can never be infected", a.Name)
+		case !engine.WithinReach(prev, a):
+			return plan, fmt.Errorf("%s is neither a contact of %s nor found in the same place", a.Name, prev.Name)
+		case engine.Relation(prev, a) == engine.RelationRival:
+			return plan, fmt.Errorf("%s is a rival of %s and cannot catch the virus from it", a.Name, prev.Name)
+		case a.Level == prev.Level:
+			sameLevel++
+		case a.Level == prev.Level+1, a.Level > prev.Level && engine.PreysOn(a, prev):
+			nextLevel++
+		default:
+			return plan, fmt.Errorf("%s (level %d) is not reachable from %s (level %d)", a.Name, a.Level, prev.Name, prev.Level)
+		}
+
+		carriers := 0
+		for name := range infected {
+			if engine.IsAdjacent(a, state.Animals[name]) {
+				carriers++
+			}
+		}
+		for _, phase := range a.ResistancePhases {
+			if carriers < phase.Carriers {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s's %s needs %d carriers but the route only provides %d", a.Name, phase.Name, phase.Carriers, carriers))
+			}
+			for _, m := range phase.Modes {
+				if !state.Virus.HasMode(m) {
+					plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s's %s needs %s mode", a.Name, phase.Name, m))
+				}
+			}
+		}
+
+		step.Chance = approxChance(state, a)
+		for r := 0; r < step.Rolls; r++ {
+			p := step.Chance
+			if mercy {
+				p, mercy = 1, false
+			}
+			if p <= 0 {
+				return plan, fmt.Errorf("%s can never be infected", a.Name)
+			}
+			failures := (1 - p) / p
+			step.ExpectedAttempts += 1 + failures
+			// A success ends the day; a failure costs one phase of it.
+			step.ExpectedDays += 1 + failures/3
+			plan.SuccessProbability *= p
+		}
+
+		plan.ExpectedAttempts += step.ExpectedAttempts
+		plan.ExpectedDays += step.ExpectedDays
+		plan.Steps = append(plan.Steps, step)
+		infected[a.Name] = true
+	}
+
+	last := route[len(route)-1]
+	if state.Rules.OutbreakPercent == 0 && last.Level != state.MaxLevel {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("route ends at level %d; the apex is level %d", last.Level, state.MaxLevel))
+	} else if state.Rules.OutbreakPercent == 0 {
+		plan.Ending, plan.EndingBonus = engine.EndingLine(last), engine.EndingBonus(state, last)
+	}
+
+	secs := plan.ExpectedAttempts * planSecondsPerAttempt
+	score := float64(engine.ScoreBase+nextLevel*engine.ScoreNextLevelBonus-sameLevel*engine.ScoreSameLevelPenalty+plan.EndingBonus) -
+		plan.ExpectedAttempts*engine.ScoreAttemptPenalty - engine.ScoringOf(state).Penalty(secs)
+	if score < 0 {
+		score = 0
+	}
+	plan.ExpectedScore = int(score * state.Rules.ScoreMultiplier())
+	return plan, nil
+}
+
+// ScoreBound is a rough best score for a map: the shortest climb to the apex with every
+// roll succeeding first try and no time spent, ending on whichever apex scores best with
+// its ending bonus. Carrier requirements and which hosts meet in the food web are
+// ignored, so its route may not be playable; it is an approximate bound, not a result
+// any run is guaranteed to reach.
+type ScoreBound struct {
+	Route []string `json:"route"`
+	Rolls int      `json:"rolls"`
+	Score int      `json:"score"`
+}
+
+// scoreBound returns the rough best score for state's map and rules. It is only defined
+// for apex victory, and ok is false when the apex cannot be reached.
+func scoreBound(state *engine.GameState) (best ScoreBound, ok bool) {
+	if state.Rules.OutbreakPercent > 0 || state.MaxLevel == 0 {
+		return best, false
+	}
+
+	pick := map[int]*engine.Animal{}
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.RedHerring || a.Juvenile || approxChance(state, a) <= 0 {
+			continue
+		}
+		// An apex is worth its ending bonus less its extra rolls.
+		worth := func(a *engine.Animal) int {
+			return engine.EndingBonus(state, a) - len(a.ResistancePhases)*engine.ScoreAttemptPenalty
+		}
+		cur, seen := pick[a.Level]
+		if !seen || worth(a) > worth(cur) ||
+			(worth(a) == worth(cur) && approxChance(state, a) > approxChance(state, cur)) {
+			pick[a.Level] = a
+		}
+	}
+
+	first := engine.StarterLevel(state)
+	for lvl := first; lvl <= state.MaxLevel; lvl++ {
+		a, found := pick[lvl]
+		if !found {
+			return ScoreBound{}, false
+		}
+		best.Route = append(best.Route, a.Name)
+		if lvl > first {
+			best.Rolls += 1 + len(a.ResistancePhases)
+		}
+	}
+
+	score := engine.ScoreBase + (state.MaxLevel-first)*engine.ScoreNextLevelBonus - best.Rolls*engine.ScoreAttemptPenalty + engine.EndingBonus(state, pick[state.MaxLevel])
+	if score < 0 {
+		score = 0
+	}
+	best.Score = int(float64(score) * state.Rules.ScoreMultiplier())
+	return best, true
+}
+
+// runPlan implements the "plan" subcommand:
+//
+//	plan [--map file] [--json] [--mercy] "Deer Mouse" "Red Fox" Coyote "Gray Wolf" "Human Ranger"
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	mapPath := fs.String("map", engine.DefaultMapPath, "map file to plan against")
+	asJSON := fs.Bool("json", false, "print the plan as JSON")
+	mercy := fs.Bool("mercy", false, "plan with the mercy rule on")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: plan [flags] <patient zero> <host> <host> ...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	state := engine.NewGameState(*mapPath)
+	state.Rules.MercyRule = *mercy
+
+	var route []*engine.Animal
+	for _, name := range fs.Args() {
+		a, err := findAnimal(state, name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Plan error:", err)
+			return 1
+		}
+		route = append(route, a)
+	}
+
+	plan, err := PlanRoute(state, route)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Plan error:", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(plan)
+		return 0
+	}
+	printPlan(os.Stdout, plan)
+	return 0
+}
+
+func printPlan(w io.Writer, plan RoutePlan) {
+	fmt.Fprintf(w, "%-24s %5s %7s %6s %9s %6s\n", "HOST", "LEVEL", "CHANCE", "ROLLS", "ATTEMPTS", "DAYS")
+	for _, s := range plan.Steps {
+		fmt.Fprintf(w, "%-24s %5d %6.0f%% %6d %9.1f %6.1f", s.Animal, s.Level, s.Chance*100, s.Rolls, s.ExpectedAttempts, s.ExpectedDays)
+		if s.Note != "" {
+			fmt.Fprintf(w, "  (%s)", s.Note)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, strings.Repeat("-", 62))
+	fmt.Fprintf(w, "Expected attempts:   %.1f\n", plan.ExpectedAttempts)
+	fmt.Fprintf(w, "Expected days:       %.1f\n", plan.ExpectedDays)
+	fmt.Fprintf(w, "First-try success:   %.1f%%\n", plan.SuccessProbability*100)
+	fmt.Fprintf(w, "Expected score:      %d\n", plan.ExpectedScore)
+	if plan.Ending != "" {
+		fmt.Fprintf(w, "Ending:              %s\n", plan.Ending)
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintln(w, "Warning:", warning)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"yellowstone_evolution/engine"
+)
+
+// rivalMap has two level 1 animals that share a meadow but are rivals.
+const rivalMap = `{
+  "Level1": [
+    {"Name": "Mouse", "Level": 1, "Mobility": "Walk", "Intelligence": 1, "InfectionRate": 0.5, "Location": "Meadow", "Contacts": [{"Name": "Vole", "Kind": "rival"}]},
+    {"Name": "Vole", "Level": 1, "Mobility": "Walk", "Intelligence": 1, "InfectionRate": 0.5, "Location": "Meadow"}
+  ]
+}`
+
+// TestPlanRouteSameLevelRival checks that the planner refuses a step to a rival even
+// when it is on the host's own level.
+func TestPlanRouteSameLevelRival(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rivals.json")
+	if err := os.WriteFile(path, []byte(rivalMap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state := engine.NewGameState(path)
+	route := []*engine.Animal{state.Animals["Mouse"], state.Animals["Vole"]}
+	_, err := PlanRoute(state, route)
+	if err == nil || !strings.Contains(err.Error(), "rival") {
+		t.Fatalf("planning Mouse → Vole gave %v, want a rival error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== PRACTICE MODE =====
+
+// practiceControls are the header buttons for undo and branches. Branch names are free
+// text, so they are recorded as "branch:<name>" and "revert:<name>".
+func practiceControls(state *engine.GameState, win fyne.Window, redraw func()) fyne.CanvasObject {
+	undo := newButton("↶ Undo", inputs.Bind("undo", func() {
+		if state.Undo != nil {
+			state.Undo.Restore(state)
+			state.Undo = nil
+			redraw()
+		}
+	}))
+	if state.Undo == nil {
+		undo.Disable()
+	}
+
+	branch := newButton("🧪 Branch here", func() {
+		state.Stats.Clock.Pause()
+		name := widget.NewEntry()
+		name.SetText(fmt.Sprintf("Day %d %s", state.CurrentDay, state.Phase))
+		dialog.ShowForm("Save Branch Point", "Save", "Cancel",
+			[]*widget.FormItem{widget.NewFormItem("Name", name)},
+			func(ok bool) {
+				state.Stats.Clock.Resume()
+				if ok && name.Text != "" {
+					inputs.Record("branch:" + name.Text)
+					engine.SaveBranch(state, name.Text)
+					redraw()
+				}
+			}, win)
+	})
+	inputs.HandlePrefix("branch:", func(name string) {
+		engine.SaveBranch(state, name)
+		redraw()
+	})
+
+	var names []string
+	for _, b := range state.Branches {
+		names = append(names, b.Name)
+	}
+	revert := widget.NewSelect(names, func(name string) {
+		inputs.Record("revert:" + name)
+		if engine.RevertToBranch(state, name) {
+			redraw()
+		}
+	})
+	revert.PlaceHolder = "↩ Revert to…"
+	inputs.HandlePrefix("revert:", func(name string) {
+		if engine.RevertToBranch(state, name) {
+			redraw()
+		}
+	})
+
+	return container.NewHBox(newLabel("🧪 Practice"), undo, branch, revert)
+}
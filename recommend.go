@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== DIFFICULTY RECOMMENDATIONS =====
+//
+// The intro screen suggests a difficulty from the profile's recent ranked runs: how
+// often they were won, and how many attempts the wins took compared with a perfect run
+// of the map. A player who keeps losing is offered an easier tier, one who wins
+// efficiently a harder one.
+
+const (
+	recommendWindow  = 20   // most recent ranked runs considered
+	recommendMinRuns = 3    // fewer than this and no recommendation is made
+	recommendEasier  = 0.4  // win rates below this suggest an easier tier
+	recommendHarder  = 0.75 // win rates at or above this may suggest a harder one
+	// recommendEfficient is how many times the perfect run's attempts a player's wins may
+	// take on average and still count as efficient.
+	recommendEfficient = 2.0
+)
+
+// DifficultyTier is a named set of rules offered as a recommendation.
+type DifficultyTier struct {
+	Name  string
+	Rules engine.RulesConfig
+}
+
+// difficultyTiers run from easiest to hardest.
+var difficultyTiers = []DifficultyTier{
+	{"Gentle", engine.RulesConfig{MercyRule: true}},
+	{"Standard", engine.RulesConfig{}},
+	{"Hard", engine.RulesConfig{RetryCooldown: true, AttemptBudget: engine.BudgetOptions[1]}},
+	{"Brutal", engine.RulesConfig{RetryCooldown: true, AttemptBudget: engine.BudgetOptions[0], WildGenetics: true, ContactWeighted: true}},
+}
+
+// tierOf places a run's rules on the difficulty ladder: mercy makes it gentle, and each
+// rule that makes a run harder moves it up.
+func tierOf(r engine.RulesConfig) int {
+	if r.MercyRule {
+		return 0
+	}
+	hard := 0
+	for _, on := range []bool{r.RetryCooldown, r.AttemptBudget > 0, r.WildGenetics, r.ContactWeighted} {
+		if on {
+			hard++
+		}
+	}
+	switch {
+	case hard >= 3:
+		return 3
+	case hard > 0:
+		return 2
+	}
+	return 1
+}
+
+// runTier is tierOf for a recorded run. Runs recorded before the full rules were kept
+// only disclose the mercy rule.
+func runTier(r RunRecord) int {
+	if r.Ruleset != nil {
+		return tierOf(*r.Ruleset)
+	}
+	if strings.Contains(r.Rules, "mercy") {
+		return 0
+	}
+	return 1
+}
+
+// Recommendation is a suggested tier and the reasons for it.
+type Recommendation struct {
+	Tier DifficultyTier
+	Why  []string
+}
+
+// recommendDifficulty suggests a tier from runs (oldest first). Runs on mapName are
+// preferred when there are enough of them; par is the attempts a perfect run of the
+// map takes, or 0 if unknown. ok is false without enough ranked history.
+func recommendDifficulty(runs []RunRecord, mapName string, par int) (rec Recommendation, ok bool) {
+	var ranked, onMap []RunRecord
+	for _, r := range runs {
+		if slices.Contains(r.Tags, "practice") {
+			continue
+		}
+		ranked = append(ranked, r)
+		if r.Map == mapName {
+			onMap = append(onMap, r)
+		}
+	}
+	where := "on this map"
+	if len(onMap) < recommendMinRuns {
+		onMap, where = ranked, "across all maps"
+	}
+	if len(onMap) < recommendMinRuns {
+		return rec, false
+	}
+	if len(onMap) > recommendWindow {
+		onMap = onMap[len(onMap)-recommendWindow:]
+	}
+
+	wins, attempts := 0, 0
+	for _, r := range onMap {
+		if r.Won {
+			wins++
+			attempts += r.Attempts
+		}
+	}
+	rate := float64(wins) / float64(len(onMap))
+	current := runTier(onMap[len(onMap)-1])
+	rec.Why = append(rec.Why, fmt.Sprintf("You won %d of your last %d ranked runs %s (%.0f%%), most recently on %s.",
+		wins, len(onMap), where, rate*100, difficultyTiers[current].Name))
+
+	next := current
+	switch {
+	case rate < recommendEasier:
+		next--
+		rec.Why = append(rec.Why, fmt.Sprintf("That is under %.0f%%, so an easier tier should be more fun.", recommendEasier*100))
+	case rate >= recommendHarder:
+		avg := float64(attempts) / float64(wins)
+		if par > 0 && avg > recommendEfficient*float64(par) {
+			rec.Why = append(rec.Why, fmt.Sprintf("Your wins took %.1f attempts on average against %d for a perfect run; tightening your route is the next challenge.", avg, par))
+			break
+		}
+		next++
+		line := fmt.Sprintf("That is %.0f%% or more", recommendHarder*100)
+		if par > 0 {
+			line += fmt.Sprintf(", and your wins took %.1f attempts on average against %d for a perfect run", avg, par)
+		}
+		rec.Why = append(rec.Why, line+", so you are ready for more.")
+	default:
+		rec.Why = append(rec.Why, "That is a good balance, so stay where you are.")
+	}
+
+	switch {
+	case next < 0:
+		next = 0
+		rec.Why = append(rec.Why, "This is already the easiest tier.")
+	case next >= len(difficultyTiers):
+		next = len(difficultyTiers) - 1
+		rec.Why = append(rec.Why, "This is already the hardest tier.")
+	}
+	rec.Tier = difficultyTiers[next]
+	return rec, true
+}
+
+// recommendationPanel offers the recommendation on the intro screen, or nothing when
+// there is not enough history. Applying it keeps the victory condition and practice
+// choice, which are not about difficulty.
+func recommendationPanel(state *engine.GameState, redraw func()) fyne.CanvasObject {
+	par := 0
+	if best, ok := scoreBound(state); ok {
+		par = best.Rolls
+	}
+	rec, ok := recommendDifficulty(LoadRuns(), filepath.Base(state.MapPath), par)
+	if !ok {
+		return container.NewWithoutLayout()
+	}
+
+	why := newLabel(strings.Join(rec.Why, "\n"))
+	apply := newButton("Use "+rec.Tier.Name, inputs.Bind("recommend", func() {
+		rules := rec.Tier.Rules
+		rules.OutbreakPercent = state.Rules.OutbreakPercent
+		rules.Practice = state.Rules.Practice
+		state.Rules = rules
+		redraw()
+	}))
+	if tierOf(state.Rules) == tierOf(rec.Tier.Rules) {
+		apply.Disable()
+	}
+	return widget.NewAccordion(widget.NewAccordionItem(plain("💡 Suggested difficulty: "+rec.Tier.Name), container.NewVBox(why, apply)))
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== REPLAYS =====
+//
+// Every run the player plays is recorded as a replay: each decision (patient zero,
+// attempts, waits, bursts, priority targets, upgrades, journal notes) as a batch mode
+// action, with the raw draws the run's random source gave while it was resolved. When
+// the run ends the replay is written to the replays directory, one file per run, ready
+// to be shared.
+//
+// Playing a replay back feeds the recorded draws to the actions in place of the seed,
+// so a resumed save or a practice undo cannot make it diverge. Each step checks that it
+// used exactly its own draws and got the recorded result; if not, the map or the rules
+// changed since, and playback stops there. "rawr replay" steps through a replay in the
+// terminal, and the Replays screen scrubs through one on a timeline.
+
+const replayVersion = 1
+
+// ReplayLog is a replay file: how the run was set up, how it ended, and every step.
+type ReplayLog struct {
+	Version         int                 `json:"version"`
+	Date            time.Time           `json:"date"`
+	Stamp           engine.Stamp        `json:"stamp"`
+	Map             string              `json:"map"`
+	Seed            int64               `json:"seed"`
+	Rules           engine.RulesConfig  `json:"rules"`
+	NGPlus          int                 `json:"ng_plus,omitempty"`
+	CarriedMutation string              `json:"carried_mutation,omitempty"`
+	Won             bool                `json:"won"`
+	Host            string              `json:"host"`
+	Score           int                 `json:"score"`
+	Steps           []engine.ReplayStep `json:"steps"`
+
+	path string
+}
+
+func (l ReplayLog) Label() string {
+	result := "lost"
+	if l.Won {
+		result = "won"
+	}
+	return fmt.Sprintf("%s — %s — %s as %s, score %d — %d steps", l.Date.Local().Format("2006-01-02 15:04"), filepath.Base(l.Map), result, l.Host, l.Score, len(l.Steps))
+}
+
+// drawPlayer is the source of a replayed run's generator: it hands out the recorded
+// draws in order.
+type drawPlayer struct {
+	draws []int64
+	next  int
+	// short is set when a step asked for more draws than were recorded.
+	short bool
+}
+
+func (p *drawPlayer) Int63() int64 {
+	if p.next >= len(p.draws) {
+		p.short = true
+		return 0
+	}
+	p.next++
+	return p.draws[p.next-1]
+}
+
+func (p *drawPlayer) Seed(int64) {}
+
+func replaysDir() string {
+	return filepath.Join(dataDirs.Profile, "replays")
+}
+
+// saveReplay writes the finished run's replay and returns its path.
+func saveReplay(state *engine.GameState) (string, error) {
+	if dataDirs.Profile == "" || len(state.Replay) == 0 {
+		return "", nil
+	}
+	l := ReplayLog{
+		Version:         replayVersion,
+		Date:            time.Now(),
+		Stamp:           engine.CurrentStamp(state.MapPath),
+		Map:             state.MapPath,
+		Seed:            state.Seed,
+		Rules:           state.Rules,
+		NGPlus:          state.NGPlus,
+		CarriedMutation: state.CarriedMutation,
+		Won:             engine.HasWon(state),
+		Host:            state.PlayerName,
+		Score:           engine.CalculateScore(state),
+		Steps:           state.Replay,
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(replaysDir(), 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(replaysDir(), l.Date.Format("2006-01-02-150405")+".json")
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+func LoadReplay(path string) (*ReplayLog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var l ReplayLog
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%s is not a replay: %w", path, err)
+	}
+	if l.Version > replayVersion {
+		return nil, fmt.Errorf("%s was recorded by a newer version (replay format %d)", path, l.Version)
+	}
+	l.path = path
+	return &l, nil
+}
+
+// LoadReplays returns the saved replays, newest first; unreadable files are skipped.
+func LoadReplays() []*ReplayLog {
+	paths, _ := filepath.Glob(filepath.Join(replaysDir(), "*.json"))
+	var out []*ReplayLog
+	for _, p := range paths {
+		l, err := LoadReplay(p)
+		if err != nil {
+			slog.Warn("replay unreadable", "path", p, "err", err)
+			continue
+		}
+		out = append(out, l)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Date.After(out[j].Date) })
+	return out
+}
+
+// replayMap finds the replay's map: the recorded path, or the user's or bundled map of
+// the same name for a replay recorded on another machine.
+func replayMap(l *ReplayLog) string {
+	if engine.FileExists(l.Map) {
+		return l.Map
+	}
+	return dataDirs.Find(dataDirs.Maps, filepath.Join(bundledMapsDir, filepath.Base(l.Map)))
+}
+
+// replayStale describes how the build or map differs from the ones l was recorded
+// with, or returns "".
+func replayStale(l *ReplayLog) string {
+	return l.Stamp.Mismatch(engine.CurrentStamp(replayMap(l)))
+}
+
+// ErrReplayDiverged means playing a step back did not go the way it was recorded.
+var ErrReplayDiverged = errors.New("replay diverged")
+
+// Playback is a replay being played back on a fresh run.
+type Playback struct {
+	log   *ReplayLog
+	state *engine.GameState
+	src   *drawPlayer
+	next  int
+}
+
+// newPlayback sets the replay's run up as it started, before any step.
+func newPlayback(l *ReplayLog) (*Playback, error) {
+	mapPath := replayMap(l)
+	if err := validateMap(mapPath); err != nil {
+		return nil, err
+	}
+	state := engine.NewGameState(mapPath)
+	state.Rules = l.Rules
+	state.Seed = l.Seed
+	state.BotPlayed = true
+	applyNewGamePlus(state, l.NGPlus, l.CarriedMutation)
+
+	var draws []int64
+	for _, s := range l.Steps {
+		draws = append(draws, s.Draws...)
+	}
+	p := &Playback{log: l, state: state, src: &drawPlayer{draws: draws}}
+	state.RNG = rand.New(p.src)
+	return p, nil
+}
+
+// Step plays the next step back.
+func (p *Playback) Step() (engine.ReplayStep, error) {
+	step := p.log.Steps[p.next]
+	before := p.src.next
+	summary := playBatch(p.state, []string{step.Action})
+	p.next++
+
+	used := p.src.next - before
+	switch {
+	case summary.Error != "":
+		return step, fmt.Errorf("%w at step %d: %s", ErrReplayDiverged, p.next, summary.Error)
+	case len(summary.Steps) == 1 && summary.Steps[0].Result != step.Result:
+		return step, fmt.Errorf("%w at step %d: %s was %s, now %s", ErrReplayDiverged, p.next, step.Action, step.Result, summary.Steps[0].Result)
+	case p.src.short || used != len(step.Draws):
+		return step, fmt.Errorf("%w at step %d: %s rolled %d times, now %d", ErrReplayDiverged, p.next, step.Action, len(step.Draws), used)
+	}
+	return step, nil
+}
+
+func (p *Playback) Done() bool {
+	return p.next >= len(p.log.Steps)
+}
+
+// playReplayTo plays the first n steps of l back and returns the run as it stood.
+func playReplayTo(l *ReplayLog, n int) (*engine.GameState, error) {
+	p, err := newPlayback(l)
+	if err != nil {
+		return nil, err
+	}
+	for p.next < n && !p.Done() {
+		if _, err := p.Step(); err != nil {
+			return p.state, err
+		}
+	}
+	return p.state, nil
+}
+
+// stepLine describes a recorded step, e.g. "Day 3 Morning — infect Red Fox → infected".
+func stepLine(s engine.ReplayStep) string {
+	return fmt.Sprintf("Day %d %s — %s → %s", s.Day, s.Phase, s.Action, s.Result)
+}
+
+// replaySummary describes where a replayed run stands: its host, the infected and
+// immune animals, and what happened overnight.
+func replaySummary(state *engine.GameState) []string {
+	host := state.Animals[state.PlayerName]
+	if host == nil {
+		return []string{"No patient zero yet."}
+	}
+	lines := []string{fmt.Sprintf("Host: %s (level %d of %d), %d attempts", host.Name, host.Level, state.MaxLevel, state.Stats.Attempts)}
+	var infected, immune []string
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.Infected {
+			infected = append(infected, name)
+		}
+		if engine.IsImmune(state, a) {
+			immune = append(immune, name)
+		}
+	}
+	lines = append(lines, "Infected: "+strings.Join(infected, ", "))
+	if len(immune) > 0 {
+		lines = append(lines, "Immune: "+strings.Join(immune, ", "))
+	}
+	if len(state.DayReport) > 0 {
+		lines = append(lines, "Overnight: "+strings.Join(state.DayReport, " · "))
+	}
+	return lines
+}
+
+// boardLines describe the board of a replayed run for the terminal.
+func boardLines(state *engine.GameState) []string {
+	lines := replaySummary(state)
+	if state.PlayerName == "" {
+		return lines
+	}
+	for _, a := range engine.CandidateTargets(state) {
+		status := engine.BlockedReason(state, a)
+		if status == "" {
+			status = "Ready"
+		}
+		lines = append(lines, fmt.Sprintf("  %-22s L%d %5s  %s", a.Name, a.Level, engine.ChanceText(state, engine.InfectionChance(state, a)), status))
+	}
+	return lines
+}
+
+// runReplay implements the "replay" subcommand:
+//
+//	replay [--all] <replay.json>
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	all := fs.Bool("all", false, "print every step without waiting for Enter")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: replay [--all] <replay.json>")
+		fmt.Fprintln(fs.Output(), "Replays of finished runs are kept in the replays folder of the profile directory.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	initDataDirs()
+
+	l, err := LoadReplay(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Replay error:", err)
+		return 1
+	}
+	p, err := newPlayback(l)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Replay error:", err)
+		return 1
+	}
+	if stale := replayStale(l); stale != "" {
+		fmt.Fprintln(os.Stderr, "Replay warning: recorded with", stale)
+	}
+	fmt.Println(l.Label())
+	fmt.Printf("Seed %d — %s — %s\n", l.Seed, l.Rules.VictoryDescription(), l.Rules.BudgetDescription())
+
+	in := bufio.NewReader(os.Stdin)
+	for !p.Done() {
+		if !*all {
+			fmt.Print("\n[Enter for the next step, q to quit] ")
+			line, err := in.ReadString('\n')
+			if err != nil || strings.TrimSpace(line) == "q" {
+				return 0
+			}
+		}
+		step, err := p.Step()
+		fmt.Printf("\n%d/%d %s (score %d)\n", p.next, len(l.Steps), stepLine(step), step.Score)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Replay error:", err)
+			return 1
+		}
+		for _, line := range boardLines(p.state) {
+			fmt.Println("  " + line)
+		}
+	}
+	return 0
+}
+
+func createReplaysScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("🎞 Replays"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	watch := func(l *ReplayLog) {
+		win.SetContent(createReplayViewer(app, win, state, l))
+	}
+	open := newButton("📂 Open a replay file…", func() {
+		dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if r == nil {
+				return
+			}
+			path := r.URI().Path()
+			r.Close()
+
+			l, err := LoadReplay(path)
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			watch(l)
+		}, win)
+	})
+
+	list := container.NewVBox()
+	replays := LoadReplays()
+	if len(replays) == 0 {
+		list.Add(newLabel("No replays yet: finish a run and it is kept here."))
+	}
+	for _, l := range replays {
+		name := filepath.Base(l.path)
+		list.Add(container.NewBorder(nil, nil, nil,
+			newButton("▶ Watch", inputs.Bind("replay:"+name, func() { watch(l) })),
+			newLabel(l.Label())))
+	}
+
+	header := container.NewVBox(container.NewBorder(nil, nil, back, nil, title), container.NewCenter(open))
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, container.NewScroll(list))))
+}
+
+// createReplayViewer scrubs through l on a timeline: the board is played back to the
+// chosen step and drawn as it stood then.
+func createReplayViewer(app fyne.App, win fyne.Window, state *engine.GameState, l *ReplayLog) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		useEcosystem(state)
+		win.SetContent(createReplaysScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("🎞 "+l.Label()), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	title.Truncation = fyne.TextTruncateEllipsis
+
+	stale := replayStale(l)
+	caption := newLabel("")
+	caption.Alignment = fyne.TextAlignCenter
+	board := container.NewStack()
+
+	show := func(n int) {
+		played, err := playReplayTo(l, n)
+		switch {
+		case played == nil:
+			caption.SetText(err.Error())
+			board.Objects = nil
+		default:
+			useEcosystem(played)
+			text := "Before the first move"
+			if n > 0 {
+				s := l.Steps[n-1]
+				text = fmt.Sprintf("Step %d of %d — %s — score %d", n, len(l.Steps), stepLine(s), s.Score)
+			}
+			if err != nil {
+				text += "\n⚠ " + err.Error()
+			} else if stale != "" {
+				text += "\n⚠ Recorded with " + stale
+			}
+			caption.SetText(plain(text))
+			board.Objects = []fyne.CanvasObject{replayBoard(app, played)}
+		}
+		board.Refresh()
+	}
+
+	slider := widget.NewSlider(0, float64(len(l.Steps)))
+	slider.Step = 1
+	slider.OnChangeEnded = func(v float64) {
+		inputs.Record(fmt.Sprintf("replay-step:%d", int(v)))
+		show(int(v))
+	}
+	inputs.HandlePrefix("replay-step:", func(v string) {
+		if n, err := strconv.Atoi(v); err == nil {
+			slider.SetValue(float64(n))
+			show(n)
+		}
+	})
+	move := func(by int) func() {
+		return func() {
+			n := min(max(int(slider.Value)+by, 0), len(l.Steps))
+			slider.SetValue(float64(n))
+			slider.OnChangeEnded(float64(n))
+		}
+	}
+	prev := newButton("⏮", move(-1))
+	next := newButton("⏭", move(1))
+
+	slider.SetValue(float64(len(l.Steps)))
+	show(len(l.Steps))
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, back, nil, title),
+		container.NewBorder(nil, nil, prev, next, slider),
+		caption,
+	)
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, container.NewScroll(board))))
+}
+
+// replayBoard draws a played-back run's board without any controls: the infected
+// animals, then the targets as the host saw them.
+func replayBoard(app fyne.App, played *engine.GameState) fyne.CanvasObject {
+	summary := newLabel(strings.Join(replaySummary(played), "\n"))
+	summary.Wrapping = fyne.TextWrapWord
+	if played.Animals[played.PlayerName] == nil {
+		return summary
+	}
+
+	var cards []boardCard
+	for _, target := range engine.CandidateTargets(played) {
+		t := target
+		status := engine.BlockedReason(played, t)
+		if status == "" {
+			status = "Ready"
+		}
+		cards = append(cards, func(featured bool) fyne.CanvasObject {
+			img := loadAnimalImage(t.GetImagePath(), portraitSize(featured))
+			return container.NewVBox(container.NewCenter(img), container.NewCenter(newLabel(t.Name)),
+				container.NewCenter(newLabel("Chance: "+engine.ChanceText(played, engine.InfectionChance(played, t)))),
+				container.NewCenter(newLabel(status)))
+		})
+	}
+	return container.NewVBox(summary, adaptiveBoard(gridColumns(app), cards))
+}
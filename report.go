@@ -0,0 +1,393 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== MAP REPORT =====
+//
+// "rawr report map.json" writes a self-contained HTML page about a map for sharing when
+// it is published: how the animals spread over the levels, how well the contact graph
+// holds together, how long a run takes from each starter, and where the red herrings
+// sit among the real hosts. Run lengths are bot-played like the lint estimate, so the
+// same map and rules always give the same report.
+
+// LevelRow is one bar of the level distribution chart.
+type LevelRow struct {
+	Level        int
+	Hosts        int
+	RedHerrings  int
+	HostsPct     float64
+	RedHerrPct   float64
+	Animals      []string
+	SingleHost   bool
+	StarterLevel bool
+}
+
+// ContactReport summarizes the contact graph: Components are its connected groups of
+// animals, largest first.
+type ContactReport struct {
+	Edges      int
+	MeanDegree float64
+	Components [][]string
+	Isolated   []string
+	// Dangling are contacts naming animals that are not on the map.
+	Dangling []string
+}
+
+// StarterRow is the bot's run length from one patient zero.
+type StarterRow struct {
+	Name    string
+	Rate    float64
+	Est     RunEstimate
+	Fastest bool
+}
+
+// HerringRow places one red herring among the real hosts of its level.
+type HerringRow struct {
+	Name     string
+	Level    int
+	Location string
+	Rate     float64
+	// Decoys are the real hosts on its level; SameLocation those sharing its location.
+	Decoys       int
+	SameLocation int
+	// Tempting is set when no real host on its level looks likelier to infect.
+	Tempting  bool
+	HostLinks int
+	HasFacts  bool
+}
+
+// MapReport is everything the report page shows.
+type MapReport struct {
+	Map       string
+	Generated time.Time
+	Stamp     engine.Stamp
+	Rules     string
+	MaxLevel  int
+	Animals   int
+	Route     []string
+	Reachable bool
+	Levels    []LevelRow
+	Contacts  ContactReport
+	Starters  []StarterRow
+	Herrings  []HerringRow
+	Runs      int
+}
+
+// buildMapReport analyzes the map at mapPath, with assets ("" for the bundled ones) as
+// a custom map would be loaded, under rules.
+func buildMapReport(mapPath, assets string, rules engine.RulesConfig) (MapReport, error) {
+	if err := validateMap(mapPath); err != nil {
+		return MapReport{}, err
+	}
+	state := engine.NewGameState(mapPath)
+	state.Rules = rules
+	useAssets(state, assets)
+
+	active := []string{rules.VictoryDescription()}
+	for _, t := range rules.Toggles() {
+		if t.On {
+			active = append(active, t.Name)
+		}
+	}
+
+	an := analyzeMap(state)
+	report := MapReport{
+		Map:       filepath.Base(mapPath),
+		Generated: time.Now(),
+		Stamp:     engine.CurrentStamp(mapPath),
+		Rules:     strings.Join(active, ", "),
+		MaxLevel:  state.MaxLevel,
+		Animals:   len(state.Animals),
+		Route:     an.BestRoute,
+		Reachable: an.Reachable,
+		Levels:    levelRows(state),
+		Contacts:  contactReport(state),
+		Herrings:  herringRows(state),
+		Runs:      estimateRuns,
+	}
+
+	fastest := -1
+	for _, a := range engine.StarterCandidates(state) {
+		if a.RedHerring {
+			continue
+		}
+		row := StarterRow{Name: a.Name, Rate: a.InfectionRate, Est: estimateRunLengthFrom(mapPath, rules, a.Name)}
+		report.Starters = append(report.Starters, row)
+		i := len(report.Starters) - 1
+		if row.Est.WinRate > 0 && (fastest < 0 || row.Est.Median < report.Starters[fastest].Est.Median) {
+			fastest = i
+		}
+	}
+	if fastest >= 0 {
+		report.Starters[fastest].Fastest = true
+	}
+	return report, nil
+}
+
+func levelRows(state *engine.GameState) []LevelRow {
+	rows := make([]LevelRow, state.MaxLevel)
+	for i := range rows {
+		rows[i].Level = i + 1
+		rows[i].StarterLevel = i+1 == engine.StarterLevel(state)
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		if a.Level < 1 || a.Level > state.MaxLevel {
+			continue
+		}
+		r := &rows[a.Level-1]
+		if a.RedHerring {
+			r.RedHerrings++
+		} else {
+			r.Hosts++
+		}
+		r.Animals = append(r.Animals, a.Name)
+	}
+	widest := 1
+	for _, r := range rows {
+		widest = max(widest, r.Hosts+r.RedHerrings)
+	}
+	for i := range rows {
+		rows[i].HostsPct = 100 * float64(rows[i].Hosts) / float64(widest)
+		rows[i].RedHerrPct = 100 * float64(rows[i].RedHerrings) / float64(widest)
+		rows[i].SingleHost = rows[i].Hosts == 1 && rows[i].Level > engine.StarterLevel(state)
+	}
+	return rows
+}
+
+// contactReport treats the contact graph as undirected, as EdgeWeight does.
+func contactReport(state *engine.GameState) ContactReport {
+	var cr ContactReport
+	names := engine.SortedAnimalNames(state)
+	neighbours := map[string][]string{}
+	for i, an := range names {
+		a := state.Animals[an]
+		for _, c := range a.Contacts {
+			if _, ok := state.Animals[c.Name]; !ok {
+				cr.Dangling = append(cr.Dangling, fmt.Sprintf("%s → %s", a.Name, c.Name))
+			}
+		}
+		for _, bn := range names[i+1:] {
+			if _, ok := engine.EdgeWeight(a, state.Animals[bn]); ok {
+				neighbours[an] = append(neighbours[an], bn)
+				neighbours[bn] = append(neighbours[bn], an)
+				cr.Edges++
+			}
+		}
+	}
+	if len(names) > 0 {
+		cr.MeanDegree = 2 * float64(cr.Edges) / float64(len(names))
+	}
+
+	seen := map[string]bool{}
+	for _, start := range names {
+		if seen[start] {
+			continue
+		}
+		if len(neighbours[start]) == 0 {
+			seen[start] = true
+			cr.Isolated = append(cr.Isolated, start)
+			continue
+		}
+		var group []string
+		queue := []string{start}
+		seen[start] = true
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			group = append(group, n)
+			for _, m := range neighbours[n] {
+				if !seen[m] {
+					seen[m] = true
+					queue = append(queue, m)
+				}
+			}
+		}
+		sort.Strings(group)
+		cr.Components = append(cr.Components, group)
+	}
+	sort.SliceStable(cr.Components, func(i, j int) bool { return len(cr.Components[i]) > len(cr.Components[j]) })
+	return cr
+}
+
+func herringRows(state *engine.GameState) []HerringRow {
+	var rows []HerringRow
+	for _, name := range engine.SortedAnimalNames(state) {
+		h := state.Animals[name]
+		if !h.RedHerring {
+			continue
+		}
+		_, facts := state.RedFacts[name]
+		row := HerringRow{Name: h.Name, Level: h.Level, Location: h.Location, Rate: h.InfectionRate, Tempting: true, HasFacts: facts}
+		for _, other := range engine.SortedAnimalNames(state) {
+			a := state.Animals[other]
+			if a.RedHerring {
+				continue
+			}
+			if _, ok := engine.EdgeWeight(h, a); ok {
+				row.HostLinks++
+			}
+			if a.Level != h.Level {
+				continue
+			}
+			row.Decoys++
+			if a.Location != "" && a.Location == h.Location {
+				row.SameLocation++
+			}
+			if a.InfectionRate > h.InfectionRate {
+				row.Tempting = false
+			}
+		}
+		row.Tempting = row.Tempting && row.Decoys > 0
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct":  func(f float64) string { return fmt.Sprintf("%.0f%%", f*100) },
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Map}} — map report</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; color: #222; }
+h1 { margin-bottom: 0; }
+.meta { color: #666; font-size: 0.9em; }
+table { border-collapse: collapse; width: 100%; margin: 0.5em 0 1.5em; }
+th, td { text-align: left; padding: 0.3em 0.6em; border-bottom: 1px solid #ddd; vertical-align: top; }
+.bar { display: flex; height: 1.2em; background: #f3f3f3; min-width: 12em; }
+.hosts { background: #2e7d32; }
+.herrings { background: #c62828; }
+.warn { color: #b26a00; }
+.good { color: #2e7d32; }
+.small { font-size: 0.85em; color: #555; }
+</style>
+</head>
+<body>
+<h1>🦠 {{.Map}}</h1>
+<p class="meta">{{.Animals}} animals over {{.MaxLevel}} levels · rules: {{.Rules}} · engine {{.Stamp.EngineVersion}}, ruleset {{.Stamp.RulesetHash}} · {{.Generated.Format "2006-01-02 15:04"}}</p>
+{{if .Reachable}}<p>Best route: {{join .Route " → "}}</p>{{else}}<p class="warn">No route reaches level {{.MaxLevel}}.</p>{{end}}
+
+<h2>Level distribution</h2>
+<table>
+<tr><th>Level</th><th>Hosts</th><th>Red herrings</th><th></th><th>Animals</th></tr>
+{{range .Levels}}<tr>
+<td>{{.Level}}{{if .StarterLevel}} (start){{end}}</td>
+<td>{{.Hosts}}{{if .SingleHost}} <span class="warn">single host</span>{{end}}</td>
+<td>{{.RedHerrings}}</td>
+<td><div class="bar"><div class="hosts" style="width: {{printf "%.1f" .HostsPct}}%"></div><div class="herrings" style="width: {{printf "%.1f" .RedHerrPct}}%"></div></div></td>
+<td class="small">{{join .Animals ", "}}</td>
+</tr>{{end}}
+</table>
+
+<h2>Contact graph</h2>
+{{with .Contacts}}<p>{{.Edges}} contacts, {{printf "%.1f" .MeanDegree}} per animal on average, in {{len .Components}} connected group(s).</p>
+<table>
+<tr><th>Size</th><th>Animals</th></tr>
+{{range .Components}}<tr><td>{{len .}}</td><td class="small">{{join . ", "}}</td></tr>{{end}}
+</table>
+{{if .Isolated}}<p class="warn">No contacts: {{join .Isolated ", "}}</p>{{end}}
+{{if .Dangling}}<p class="warn">Contacts not on the map: {{join .Dangling "; "}}</p>{{end}}
+{{end}}
+
+<h2>Run length by starter</h2>
+<p class="small">A simple bot played {{.Runs}} fixed seeds from each patient zero.</p>
+<table>
+<tr><th>Starter</th><th>Infection rate</th><th>Bot win rate</th><th>Attempts (quartiles)</th><th>Median</th></tr>
+{{range .Starters}}<tr>
+<td>{{.Name}}{{if .Fastest}} <span class="good">fastest</span>{{end}}</td>
+<td>{{pct .Rate}}</td>
+<td>{{pct .Est.WinRate}}</td>
+<td>{{.Est.Low}}–{{.Est.High}}</td>
+<td>{{.Est.Median}}</td>
+</tr>{{else}}<tr><td colspan="5" class="warn">No valid starter.</td></tr>{{end}}
+</table>
+
+<h2>Red herrings</h2>
+<table>
+<tr><th>Red herring</th><th>Level</th><th>Location</th><th>Rate</th><th>Real hosts on level</th><th>Same location</th><th>Contacts with hosts</th><th>Notes</th></tr>
+{{range .Herrings}}<tr>
+<td>{{.Name}}</td><td>{{.Level}}</td><td>{{.Location}}</td><td>{{pct .Rate}}</td>
+<td>{{.Decoys}}</td><td>{{.SameLocation}}</td><td>{{.HostLinks}}</td>
+<td>{{if .Tempting}}<span class="warn">likeliest-looking target on its level</span> {{end}}{{if not .HasFacts}}<span class="warn">no fun fact</span>{{end}}</td>
+</tr>{{else}}<tr><td colspan="8">The map has no red herrings.</td></tr>{{end}}
+</table>
+</body>
+</html>
+`))
+
+func writeMapReport(w io.Writer, report MapReport) error {
+	return reportTemplate.Execute(w, report)
+}
+
+// runReport implements the "report" subcommand:
+//
+//	report [--assets dir] [--out file] [rule flags] <map.json>
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	rules := ruleFlags(fs)
+	assets := fs.String("assets", "", "folder of the map's images and red herring facts (default: the bundled ones)")
+	out := fs.String("out", "", "HTML file to write, - for stdout (default: <map>-report.html)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: report [flags] <map.json>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	initDataDirs()
+	r, err := rules()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Report error:", err)
+		return 1
+	}
+
+	mapPath := fs.Arg(0)
+	report, err := buildMapReport(mapPath, *assets, r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Report error:", err)
+		return 1
+	}
+	if *out == "-" {
+		if err := writeMapReport(os.Stdout, report); err != nil {
+			fmt.Fprintln(os.Stderr, "Report error:", err)
+			return 1
+		}
+		return 0
+	}
+	path := *out
+	if path == "" {
+		path = strings.TrimSuffix(filepath.Base(mapPath), filepath.Ext(mapPath)) + "-report.html"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Report error:", err)
+		return 1
+	}
+	defer f.Close()
+	if err := writeMapReport(f, report); err != nil {
+		fmt.Fprintln(os.Stderr, "Report error:", err)
+		return 1
+	}
+	fmt.Println("Wrote", path)
+	return 0
+}
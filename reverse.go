@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== REVERSE MODE =====
+//
+// In reverse mode the player is the apex's immune system. The simulation bot plays the
+// outbreak on the same map with the same rules, and before each of its days the player
+// vaccinates animals so it cannot infect them. The player wins by keeping the outbreak
+// from the apex for reverseDays days, or by outlasting its attempt budget.
+
+const (
+	reverseDays        = engine.DaysPerSeason
+	reverseDosesPerDay = 1
+)
+
+// ReverseRun is a reverse-mode game. Like GameState it belongs to the UI goroutine.
+type ReverseRun struct {
+	state    *engine.GameState
+	herrings map[string]bool
+	doses    int
+	log      []string
+	over     bool
+	won      bool
+}
+
+// newReverseRun starts the outbreak from the bot's usual starter on state's map.
+func newReverseRun(state *engine.GameState) (*ReverseRun, error) {
+	starter := botStarter(state)
+	if starter == nil {
+		return nil, fmt.Errorf("the map has no starter for the outbreak")
+	}
+	state.BotPlayed = true
+	if err := engine.ChooseStarter(state, starter); err != nil {
+		return nil, err
+	}
+	return &ReverseRun{
+		state:    state,
+		herrings: map[string]bool{},
+		doses:    reverseDosesPerDay,
+		log:      []string{"The outbreak starts in " + starter.Name + "."},
+	}, nil
+}
+
+// vaccineBlocked explains why a cannot be vaccinated now, or returns "".
+func (r *ReverseRun) vaccineBlocked(a *engine.Animal) string {
+	switch {
+	case r.over:
+		return "Game over"
+	case a.Infected:
+		return "🦠 Infected"
+	case r.state.Vaccinated[a.Name]:
+		return "💉 Vaccinated"
+	case a.RedHerring:
+		return "Immune already"
+	case a.Level == r.state.MaxLevel:
+		return "👑 The apex"
+	case r.doses == 0:
+		return "No doses left today"
+	}
+	return ""
+}
+
+func (r *ReverseRun) vaccinate(a *engine.Animal) error {
+	if reason := r.vaccineBlocked(a); reason != "" {
+		return fmt.Errorf("%s: %s", a.Name, reason)
+	}
+	r.state.Vaccinated[a.Name] = true
+	r.doses--
+	return nil
+}
+
+// endDay lets the outbreak play until its day is over, then checks for a result.
+func (r *ReverseRun) endDay() {
+	state := r.state
+	day := state.CurrentDay
+	r.log = nil
+	for state.CurrentDay == day && !r.over {
+		target := botChoice(state, r.herrings)
+		if target == nil {
+			engine.AdvancePhase(state)
+			continue
+		}
+		out := engine.AttemptInfection(state, target)
+		switch out.Kind {
+		case engine.OutcomeRedHerring:
+			r.herrings[target.Name] = true
+			r.log = append(r.log, fmt.Sprintf("The outbreak wasted an attempt on %s.", target.Name))
+		case engine.OutcomeResisted:
+			r.log = append(r.log, fmt.Sprintf("%s resisted the outbreak.", target.Name))
+		case engine.OutcomeDefenseBroken:
+			r.log = append(r.log, fmt.Sprintf("The outbreak broke %s's %s.", target.Name, out.Phase.Name))
+		case engine.OutcomeInfected:
+			r.log = append(r.log, fmt.Sprintf("🦠 %s was infected.", target.Name))
+		case engine.OutcomeLatched:
+			r.log = append(r.log, fmt.Sprintf("🪝 The outbreak latched onto %s.", target.Name))
+		}
+		if out.Crit != "" {
+			r.log = append(r.log, out.Crit)
+		}
+		switch {
+		case out.Won:
+			r.over = true
+			r.log = append(r.log, "The outbreak reached the apex.")
+		case out.Lost && engine.AttemptsLeft(state) == 0:
+			r.over, r.won = true, true
+			r.log = append(r.log, "The outbreak ran out of attempts.")
+		case out.Lost:
+			r.over, r.won = true, true
+			r.log = append(r.log, "The outbreak has nowhere left to spread.")
+		}
+	}
+	if len(r.log) == 0 {
+		r.log = append(r.log, "The outbreak found nothing to attack.")
+	}
+	if !r.over && state.CurrentDay >= reverseDays {
+		r.over, r.won = true, true
+		r.log = append(r.log, fmt.Sprintf("You held the outbreak off for %d days.", reverseDays))
+	}
+	r.doses = reverseDosesPerDay
+}
+
+func createReverseScreen(app fyne.App, win fyne.Window, home *engine.GameState, r *ReverseRun) fyne.CanvasObject {
+	inputs.Reset()
+	redraw := func() { win.SetContent(createReverseScreen(app, win, home, r)) }
+	redrawScreen = redraw
+	state := r.state
+	showRun(state)
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, home))
+	}))
+	host := state.Animals[state.PlayerName]
+	status := fmt.Sprintf("🛡 Day %d of %d — %d dose(s) left — outbreak host: %s (level %d of %d)",
+		state.CurrentDay+1, reverseDays, r.doses, host.Name, host.Level, state.MaxLevel)
+	if r.over {
+		status = "🛡 You lost: the outbreak reached the apex."
+		if r.won {
+			status = "🛡 You won: the apex is safe."
+		}
+	}
+	title := widget.NewLabelWithStyle(plain(status), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	endDay := newButton("End day", inputs.Bind("end-day", func() {
+		r.endDay()
+		redraw()
+	}))
+	if r.over {
+		endDay.Disable()
+	}
+	header := container.NewVBox(container.NewBorder(nil, nil, back, endDay, title), newLabel(strings.Join(r.log, "\n")))
+
+	names := engine.SortedAnimalNames(state)
+	sort.SliceStable(names, func(i, j int) bool { return state.Animals[names[i]].Level < state.Animals[names[j]].Level })
+	var cards []fyne.CanvasObject
+	for _, name := range names {
+		a := state.Animals[name]
+		btn := newButton("💉 Vaccinate", inputs.Bind("vaccinate:"+name, func() {
+			if err := r.vaccinate(a); err != nil {
+				showInformation(state, "Cannot Vaccinate", err.Error(), win)
+				return
+			}
+			redraw()
+		}))
+		if reason := r.vaccineBlocked(a); reason != "" {
+			btn.SetText(plain(reason))
+			btn.Disable()
+		}
+		label := fmt.Sprintf("%s (level %d)", a.Name, a.Level)
+		if a.Name == state.PlayerName {
+			label += " 🦠"
+		}
+		card := container.NewVBox(container.NewCenter(loadAnimalImage(a.GetImagePath(), 90)), container.NewCenter(newLabel(label)), container.NewCenter(btn))
+		cards = append(cards, card)
+	}
+
+	board := container.NewGridWithColumns(gridColumns(app), cards...)
+	return NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewBorder(header, nil, nil, nil, container.NewScroll(container.NewVBox(board, layout.NewSpacer())))))
+}
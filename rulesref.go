@@ -0,0 +1,227 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== RULES REFERENCE =====
+//
+// The reference is built from the active RulesConfig, the map and the rule constants
+// themselves, so it always describes the rules the engine is actually applying.
+
+// RuleSection is one titled block of the rules reference.
+type RuleSection struct {
+	Title string
+	Lines []string
+}
+
+func pct(f float64) string {
+	return fmt.Sprintf("%.0f%%", f*100)
+}
+
+// rulesReference describes the rules of a run on state's map under state's rules.
+func rulesReference(state *engine.GameState) []RuleSection {
+	r := state.Rules
+	cfg := state.MapConfig
+
+	victory := RuleSection{Title: "Victory", Lines: []string{
+		r.VictoryDescription() + fmt.Sprintf(" (the apex is level %d).", state.MaxLevel),
+		r.BudgetDescription() + ".",
+		"The run is lost once no animal is left that the virus could ever reach.",
+		fmt.Sprintf("Your starter must be a level %d animal.", engine.StarterLevel(state)),
+	}}
+	if apex := engine.Apexes(state); r.OutbreakPercent == 0 && len(apex) > 1 {
+		victory.Lines = append(victory.Lines, "Taking any apex wins, and each has its own ending:")
+		for _, a := range apex {
+			victory.Lines = append(victory.Lines, engine.EndingLine(a)+".")
+		}
+	}
+
+	targeting := RuleSection{Title: "Targeting", Lines: []string{
+		"You may attempt any uninfected animal at your host's level or one level above.",
+		"If your host lists contacts, the target must also be one of them or live in the same place as your host.",
+		"A predator of your host may be attempted at any level above it; a rival of your host never can, and the virus never passes between rivals.",
+		"Animals that are away or hibernating this season, or asleep this phase, cannot be attempted.",
+		"Red herrings can never be infected; an attempt on one is wasted.",
+		"Infecting an animal one level up makes it your new host.",
+	}}
+	if r.RetryCooldown {
+		targeting.Lines = append(targeting.Lines, fmt.Sprintf("A target that resists is off limits for %d day(s).", engine.CooldownDays))
+	}
+	if r.TwoStage {
+		targeting.Lines = append(targeting.Lines,
+			"Two-stage infection: once its defenses are down, a host must first be latched onto, then taken over on a later day.",
+			fmt.Sprintf("A latch succeeds if any of %d rolls would; the takeover chance is ×%.2f, and failing it shakes the virus off.", engine.LatchChanceTries, engine.TakeoverFactor))
+	}
+
+	formula := "Chance = seasonal rate × virus strength × mood"
+	if r.ContactWeighted {
+		formula += " × contact weight"
+	}
+	chance := RuleSection{Title: "Infection chance", Lines: []string{
+		formula + ", capped at 100%.",
+		fmt.Sprintf("Alert animals are ×%.2f, panicked ones ×%.2f.", engine.MoodAlertFactor, engine.MoodPanickedFactor),
+		fmt.Sprintf("Juveniles born during the run are ×%.2f.", engine.JuvenileRateBonus),
+		fmt.Sprintf("A target with an infected symbiote is ×%.2f.", engine.SymbioteBonus),
+	}}
+	if state.NGPlus > 0 {
+		chance.Lines = append(chance.Lines, fmt.Sprintf("New Game+ %d: every rate is ×%.2f per cycle (×%.2f now).", state.NGPlus, engine.NGPlusRateFactor, math.Pow(engine.NGPlusRateFactor, float64(state.NGPlus))))
+	}
+	if n := len(state.Modifiers); n > 0 {
+		chance.Lines = append(chance.Lines, fmt.Sprintf("The map applies %d modifier(s) afterwards:", n))
+		for _, m := range state.Modifiers {
+			chance.Lines = append(chance.Lines, "  "+m.Source)
+		}
+	}
+	if r.Weekly.Active() {
+		chance.Lines = append(chance.Lines, fmt.Sprintf("Weekly mutation %s: %s", r.Weekly.Label(), r.Weekly.Description), "  "+r.Weekly.Modifier)
+	}
+	if r.MercyRule {
+		chance.Lines = append(chance.Lines, "Mercy rule: the first roll of the run always succeeds.")
+	}
+	chance.Lines = append(chance.Lines, fmt.Sprintf("Once per run a mutation burst multiplies one attempt's chance by %.0f; if that attempt is resisted, virus strength is ×%.2f for %d days.", engine.BurstChanceFactor, engine.BurstStrengthFactor, engine.BurstPenaltyDays))
+
+	scoring := RuleSection{Title: "Scoring", Lines: []string{
+		fmt.Sprintf("Start at %d.", engine.ScoreBase),
+		fmt.Sprintf("+%d for each infection one level up.", engine.ScoreNextLevelBonus),
+		fmt.Sprintf("−%d for each same-level infection.", engine.ScoreSameLevelPenalty),
+		fmt.Sprintf("−%d for each attempt.", engine.ScoreAttemptPenalty),
+		engine.ScoringOf(state).Describe(),
+	}}
+	if d := r.Disclosure(); d != "" {
+		scoring.Lines = append(scoring.Lines, fmt.Sprintf("Final score ×%.2f (%s).", r.ScoreMultiplier(), d))
+	}
+	m := engine.MedalsOf(state)
+	scoring.Lines = append(scoring.Lines, fmt.Sprintf("Medals: bronze %d, silver %d, gold %d.", m.Bronze, m.Silver, m.Gold))
+
+	mutators := RuleSection{Title: "Optional rules"}
+	for _, t := range r.Toggles() {
+		mark := "off"
+		if t.On {
+			mark = "ON"
+		}
+		mutators.Lines = append(mutators.Lines, fmt.Sprintf("%s [%s]: %s", t.Name, mark, t.Description))
+	}
+
+	events := RuleSection{Title: "Events", Lines: []string{
+		fmt.Sprintf("Seasons change every %d days.", engine.DaysPerSeason),
+		fmt.Sprintf("After a resisted attempt the target flees for %d day(s) %s of the time, or turns wary for %d days %s of the time.",
+			engine.FleeDays, pct(engine.FailFleeChance), engine.WaryDays, pct(engine.FailWaryChance)),
+		"A resisted attempt alarms the target; an infection alarms the newly infected animal's contacts.",
+		fmt.Sprintf("Moods settle one step every %d days.", engine.MoodDecayDays),
+	}}
+	if s, f := engine.CritChances(state); s > 0 || f > 0 {
+		events.Lines = append(events.Lines,
+			fmt.Sprintf("%s of infections are critical and also infect one of the target's contacts.", pct(s)),
+			fmt.Sprintf("%s of resisted attempts are critical: the rangers put every calm animal on alert, or the host is hurt and the virus drops to ×%.2f strength.", pct(f), engine.CritHostDamage))
+	}
+	if p := cfg.Population; p.BirthRate > 0 || p.DeathRate > 0 {
+		events.Lines = append(events.Lines, fmt.Sprintf("Each day every uninfected animal below the apex has a %s chance of dying (never the last of its level), and every adult up to level %d a %s chance of a birth.", pct(p.DeathRate), engine.MaxBirthLevel(p), pct(p.BirthRate)))
+	}
+	if r.WildGenetics {
+		g := cfg.Genetics
+		events.Lines = append(events.Lines, fmt.Sprintf("Wild genetics: rates vary by up to ±%s and intelligence by up to ±%d, unless an animal sets its own range.", pct(g.RateSpread), g.IntelligenceSpread))
+	}
+	if r.PassiveSpread {
+		events.Lines = append(events.Lines,
+			fmt.Sprintf("Passive spread: each morning every animal at or below the host's level with an infected contact catches the virus at ×%.2f of its chance, unless a defense still stands.", engine.SpreadFactor),
+			fmt.Sprintf("Instead of attempting, you may mark up to %d of them as priority targets; their spread chance is ×%.0f that night.", engine.PriorityLimit, engine.PriorityBonus))
+	}
+	if r.Mutations {
+		events.Lines = append(events.Lines, fmt.Sprintf("Mutations: each infection you make earns %d point(s), a level up %d; spend them in the morning on:", engine.MutationPointsSameLevel, engine.MutationPointsLevelUp))
+		for _, u := range engine.Upgrades {
+			events.Lines = append(events.Lines, fmt.Sprintf("%s, %d points, up to %d time(s): %s.", u.Name, u.Cost, u.MaxLevel, u.Description))
+		}
+	}
+	if r.Immunity {
+		events.Lines = append(events.Lines, fmt.Sprintf("Immune response: each morning every infected animal but the host recovers with a chance of %s per point of intelligence, at most %s, and can never be infected again.", pct(engine.RecoveryPerIntelligence), pct(engine.MaxRecoveryChance)))
+	}
+	if r.Curses {
+		events.Lines = append(events.Lines, "Curses: an attempt on a red herring curses the virus with one of:")
+		for _, c := range engine.Curses {
+			events.Lines = append(events.Lines, fmt.Sprintf("%s %s, %d day(s): %s.", c.Icon, c.Name, c.Days, c.Description))
+		}
+	}
+	if cfg.Script != "" {
+		events.Lines = append(events.Lines, "The map's script ("+cfg.Script+") can change animals when events happen.")
+	}
+
+	return []RuleSection{victory, targeting, chance, scoring, mutators, events}
+}
+
+func rulesText(sections []RuleSection) string {
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(s.Title + "\n")
+		for _, l := range s.Lines {
+			b.WriteString("  " + l + "\n")
+		}
+	}
+	return b.String()
+}
+
+func rulesMarkdown(sections []RuleSection) string {
+	var b strings.Builder
+	for _, s := range sections {
+		b.WriteString("## " + s.Title + "\n\n")
+		for _, l := range s.Lines {
+			b.WriteString("- " + strings.TrimSpace(l) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func createRulesScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("📜 Rules"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	header := container.NewBorder(nil, nil, back, nil, title)
+
+	body := widget.NewRichTextFromMarkdown(rulesMarkdown(rulesReference(state)))
+	body.Wrapping = fyne.TextWrapWord
+
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, container.NewScroll(body))))
+}
+
+// runRules implements the "rules" subcommand: print the reference for the given map
+// and rule flags.
+func runRules(args []string) int {
+	fs := flag.NewFlagSet("rules", flag.ContinueOnError)
+	mapFlag := fs.String("map", "", "map file (default: the user's copy, else the bundled map)")
+	rulesFromFlags := ruleFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	rules, err := rulesFromFlags()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	mapPath := initDataDirs()
+	if *mapFlag != "" {
+		mapPath = *mapFlag
+	}
+	state := engine.NewGameState(mapPath)
+	state.Rules = rules
+	fmt.Print(rulesText(rulesReference(state)))
+	return 0
+}
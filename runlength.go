@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"yellowstone_evolution/engine"
+)
+
+// ===== RUN LENGTH ESTIMATE =====
+//
+// The intro screen estimates how long a run on the chosen map and rules will take. The
+// simulation bot plays a few fixed seeds in the background for the number of attempts a
+// run needs, and the profile's finished runs say how many seconds the player spends on
+// each attempt. Estimates are cached per map, ruleset and rules for the session.
+
+const (
+	estimateRuns = 24
+	// estimateSeed is the first seed the estimate plays, so the same rules always give
+	// the same estimate.
+	estimateSeed = 1
+	// defaultSecondsPerAttempt is the pace assumed before the profile has finished runs.
+	defaultSecondsPerAttempt = 10.0
+)
+
+// RunEstimate is the spread of attempts the bot needed on the simulated seeds.
+type RunEstimate struct {
+	Low, Median, High int
+	WinRate           float64
+}
+
+// runEstimates caches estimates by seedCacheKey; only the UI goroutine touches it.
+var runEstimates = map[string]RunEstimate{}
+
+// estimateRunLength plays estimateRuns seeds with the bot. Low and High are the first
+// and third quartiles of the attempts taken, won or not.
+func estimateRunLength(mapPath string, rules engine.RulesConfig) RunEstimate {
+	return estimateRunLengthFrom(mapPath, rules, "")
+}
+
+// estimateRunLengthFrom is estimateRunLength with the bot starting from the starter
+// named, or from the likeliest one if starter is "".
+func estimateRunLengthFrom(mapPath string, rules engine.RulesConfig, starter string) RunEstimate {
+	var attempts []int
+	wins := 0
+	for i := 0; i < estimateRuns; i++ {
+		run := simulateRunFrom(mapPath, rules, estimateSeed+int64(i), starter)
+		attempts = append(attempts, run.Attempts)
+		if run.Won {
+			wins++
+		}
+	}
+	sort.Ints(attempts)
+	return RunEstimate{
+		Low:     attempts[len(attempts)/4],
+		Median:  attempts[len(attempts)/2],
+		High:    attempts[len(attempts)*3/4],
+		WinRate: float64(wins) / float64(len(attempts)),
+	}
+}
+
+// secondsPerAttempt is the player's pace over their recent finished runs, preferring
+// runs on mapName, and whether it came from their history at all.
+func secondsPerAttempt(runs []RunRecord, mapName string) (float64, bool) {
+	pace := func(runs []RunRecord) (float64, bool) {
+		if len(runs) > recommendWindow {
+			runs = runs[len(runs)-recommendWindow:]
+		}
+		secs, attempts := 0, 0
+		for _, r := range runs {
+			secs += r.GameSeconds
+			attempts += r.Attempts
+		}
+		if attempts == 0 {
+			return defaultSecondsPerAttempt, false
+		}
+		return float64(secs) / float64(attempts), true
+	}
+	var onMap []RunRecord
+	for _, r := range runs {
+		if r.Map == mapName {
+			onMap = append(onMap, r)
+		}
+	}
+	if len(onMap) >= recommendMinRuns {
+		return pace(onMap)
+	}
+	return pace(runs)
+}
+
+// formatMinutes rounds d to whole minutes, with anything under one shown as "<1".
+func formatMinutes(d time.Duration) string {
+	if m := int(d.Round(time.Minute).Minutes()); m > 0 {
+		return fmt.Sprint(m)
+	}
+	return "<1"
+}
+
+// describe puts e into words at secs seconds per attempt.
+func (e RunEstimate) describe(secs float64, personal bool) string {
+	at := func(n int) time.Duration { return time.Duration(float64(n) * secs * float64(time.Second)) }
+	low, high := formatMinutes(at(e.Low)), formatMinutes(at(e.High))
+	length := low + "–" + high + " min"
+	if low == high {
+		length = low + " min"
+	}
+	pace := "at a typical pace"
+	if personal {
+		pace = "at your usual pace"
+	}
+	return fmt.Sprintf("⏱ Expected run: %s (%d–%d attempts %s; the bot won %.0f%% of test runs)", length, e.Low, e.High, pace, e.WinRate*100)
+}
+
+// runLengthLabel shows the estimate for state's map and rules. Call the returned
+// refresh after the rules change.
+func runLengthLabel(state *engine.GameState) (fyne.CanvasObject, func()) {
+	label := newLabel("")
+	secs, personal := secondsPerAttempt(LoadRuns(), filepath.Base(state.MapPath))
+	pending := 0
+
+	var refresh func()
+	refresh = func() {
+		key := seedCacheKey(state)
+		if e, ok := runEstimates[key]; ok {
+			label.SetText(plain(e.describe(secs, personal)))
+			return
+		}
+		pending++
+		label.SetText(plain("⏱ Estimating run length…"))
+		mapPath, rules := state.MapPath, state.Rules
+		go func() {
+			e := estimateRunLength(mapPath, rules)
+			fyne.Do(func() {
+				runEstimates[key] = e
+				if pending--; pending > 0 {
+					return
+				}
+				refresh()
+			})
+		}()
+	}
+	refresh()
+	return label, refresh
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== SAVE AND RESUME =====
+//
+// A run in progress is saved to save.json in the profile after every move, so closing
+// the window loses nothing; "Continue" on the intro screen and "cli --resume" pick it
+// up again. The file is dropped once the run is won or lost.
+//
+// The run's random generator is saved as the number of draws it has made: a resumed
+// run reseeds from its seed and skips that many, so it rolls exactly as the
+// uninterrupted run would have. The map script's own variables are not saved; they
+// start over.
+
+// saveVersion is bumped whenever SavedGame changes incompatibly.
+const saveVersion = 1
+
+var ErrNoSave = errors.New("no saved game")
+
+// SavedGame is save.json: everything needed to continue a run on the same map.
+type SavedGame struct {
+	Version int          `json:"version"`
+	Saved   time.Time    `json:"saved"`
+	Stamp   engine.Stamp `json:"stamp"`
+
+	MapPath         string             `json:"map"`
+	Seed            int64              `json:"seed"`
+	DrawsUsed       int64              `json:"draws_used"`
+	RunID           int64              `json:"run_id,omitempty"`
+	Rules           engine.RulesConfig `json:"rules"`
+	NGPlus          int                `json:"ng_plus,omitempty"`
+	CarriedMutation string             `json:"carried_mutation,omitempty"`
+
+	Starter       string          `json:"starter"`
+	Host          string          `json:"host"`
+	Day           int             `json:"day"`
+	Phase         engine.DayPhase `json:"phase"`
+	VirusModes    []string        `json:"virus_modes"`
+	VirusStrength float64         `json:"virus_strength"`
+	Attempts      int             `json:"attempts"`
+	SameLevel     int             `json:"same_level"`
+	NextLevel     int             `json:"next_level"`
+	GameSeconds   float64         `json:"game_seconds"`
+
+	// Animals are saved whole, since genetics, births and deaths change them during a
+	// run; Juveniles names the ones born during it.
+	Animals   map[string]*engine.Animal `json:"animals"`
+	Juveniles []string                  `json:"juveniles,omitempty"`
+	Dead      []*engine.Animal          `json:"dead,omitempty"`
+	Births    int                       `json:"births,omitempty"`
+
+	BrokenPhases    map[string]int                 `json:"broken_phases,omitempty"`
+	Wary            map[string]engine.WaryStatus   `json:"wary,omitempty"`
+	Cooldowns       map[string]int                 `json:"cooldowns,omitempty"`
+	FailStreak      map[string]int                 `json:"fail_streak,omitempty"`
+	AdviceDismissed map[string]bool                `json:"advice_dismissed,omitempty"`
+	Moods           map[string]engine.MoodStatus   `json:"moods,omitempty"`
+	Vaccinated      map[string]bool                `json:"vaccinated,omitempty"`
+	Latched         map[string]int                 `json:"latched,omitempty"`
+	Immune          map[string]int                 `json:"immune,omitempty"`
+	Priorities      map[string]bool                `json:"priorities,omitempty"`
+	MutationPoints  int                            `json:"mutation_points,omitempty"`
+	Upgrades        map[string]int                 `json:"upgrades,omitempty"`
+	Effects         []engine.TimedEffect           `json:"effects,omitempty"`
+	BurstArmed      bool                           `json:"burst_armed,omitempty"`
+	BurstUsed       bool                           `json:"burst_used,omitempty"`
+	LastInteraction map[string]string              `json:"last_interaction,omitempty"`
+	MercyUsed       bool                           `json:"mercy_used,omitempty"`
+	Genetics        map[string]engine.GeneticShift `json:"genetics,omitempty"`
+	DayReport       []string                       `json:"day_report,omitempty"`
+	Lineage         []engine.HostStep              `json:"lineage,omitempty"`
+	Transmissions   []engine.Transmission          `json:"transmissions,omitempty"`
+	Journal         []engine.JournalNote           `json:"journal,omitempty"`
+	PassAndPlay     *engine.PassAndPlay            `json:"pass_and_play,omitempty"`
+	Replay          []engine.ReplayStep            `json:"replay,omitempty"`
+
+	// Undo and Branches are a practice run's undo and named branch points.
+	Undo     *engine.Snapshot   `json:"undo,omitempty"`
+	Branches []*engine.Snapshot `json:"branches,omitempty"`
+}
+
+func savePath() string {
+	return filepath.Join(dataDirs.Profile, "save.json")
+}
+
+// HasSave reports whether there is a run to continue.
+func HasSave() bool {
+	return dataDirs.Profile != "" && engine.FileExists(savePath())
+}
+
+func newSavedGame(state *engine.GameState) SavedGame {
+	s := SavedGame{
+		Version:         saveVersion,
+		Saved:           time.Now(),
+		Stamp:           engine.CurrentStamp(state.MapPath),
+		MapPath:         state.MapPath,
+		Seed:            state.Seed,
+		DrawsUsed:       engine.DrawsUsed(state),
+		RunID:           state.RunID,
+		Rules:           state.Rules,
+		NGPlus:          state.NGPlus,
+		CarriedMutation: state.CarriedMutation,
+		Starter:         state.Starter,
+		Host:            state.PlayerName,
+		Day:             state.CurrentDay,
+		Phase:           state.Phase,
+		VirusModes:      state.Virus.Modes,
+		VirusStrength:   state.Virus.Strength,
+		Attempts:        state.Stats.Attempts,
+		SameLevel:       state.Stats.SameLevelInfections,
+		NextLevel:       state.Stats.NextLevelInfections,
+		GameSeconds:     state.Stats.Clock.Elapsed().Seconds(),
+		Animals:         state.Animals,
+		Dead:            state.Dead,
+		Births:          state.Births,
+		BrokenPhases:    state.BrokenPhases,
+		Wary:            state.Wary,
+		Cooldowns:       state.Cooldowns,
+		FailStreak:      state.FailStreak,
+		AdviceDismissed: state.AdviceDismissed,
+		Moods:           state.Moods,
+		Vaccinated:      state.Vaccinated,
+		Latched:         state.Latched,
+		Immune:          state.Immune,
+		Priorities:      state.Priorities,
+		MutationPoints:  state.MutationPoints,
+		Upgrades:        state.Upgrades,
+		Effects:         state.Effects,
+		BurstArmed:      state.BurstArmed,
+		BurstUsed:       state.BurstUsed,
+		LastInteraction: state.LastInteraction,
+		MercyUsed:       state.MercyUsed,
+		Genetics:        state.Genetics,
+		DayReport:       state.DayReport,
+		Lineage:         state.Lineage,
+		Transmissions:   state.Transmissions,
+		Journal:         state.Journal,
+		PassAndPlay:     state.Coop,
+		Replay:          state.Replay,
+		Undo:            state.Undo,
+		Branches:        state.Branches,
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		if state.Animals[name].Juvenile {
+			s.Juveniles = append(s.Juveniles, name)
+		}
+	}
+	return s
+}
+
+// SaveGame writes the run in state to save.json. Runs that have not started, and runs
+// the bot plays, are not saved.
+func SaveGame(state *engine.GameState) error {
+	if dataDirs.Profile == "" || state.PlayerName == "" || state.BotPlayed {
+		return nil
+	}
+	data, err := json.MarshalIndent(newSavedGame(state), "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write and rename, so a crash mid-write leaves the previous save intact.
+	tmp := savePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, savePath())
+}
+
+// autosave saves the run after a move, logging rather than interrupting on failure.
+func autosave(state *engine.GameState) {
+	if err := SaveGame(state); err != nil {
+		slog.Error("game not saved", "err", err)
+	}
+}
+
+// ClearSave drops the saved game once its run is over.
+func ClearSave() {
+	if !HasSave() {
+		return
+	}
+	if err := os.Remove(savePath()); err != nil {
+		slog.Error("saved game not removed", "err", err)
+	}
+}
+
+// LoadGame rebuilds the saved run on a fresh load of its map and restarts its clock.
+func LoadGame() (*engine.GameState, error) {
+	if !HasSave() {
+		return nil, ErrNoSave
+	}
+	data, err := os.ReadFile(savePath())
+	if err != nil {
+		return nil, err
+	}
+	var s SavedGame
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("saved game unreadable: %w", err)
+	}
+	if s.Version > saveVersion {
+		return nil, fmt.Errorf("the game was saved by a newer version (save format %d, this version reads %d)", s.Version, saveVersion)
+	}
+	if !engine.FileExists(s.MapPath) {
+		return nil, fmt.Errorf("the saved game's map %s is gone", s.MapPath)
+	}
+	if stale := s.Stamp.Mismatch(engine.CurrentStamp(s.MapPath)); stale != "" {
+		slog.Warn("saved game from another version", "saved_with", stale)
+	}
+
+	state := engine.NewGameState(s.MapPath)
+	state.Seed = s.Seed
+	state.RunID = s.RunID
+	state.Rules = s.Rules
+	state.NGPlus = s.NGPlus
+	state.CarriedMutation = s.CarriedMutation
+	state.Starter = s.Starter
+	state.PlayerName = s.Host
+	state.CurrentDay = s.Day
+	state.Phase = s.Phase
+	state.Virus.Modes = s.VirusModes
+	state.Virus.Strength = s.VirusStrength
+	state.Stats.Attempts = s.Attempts
+	state.Stats.SameLevelInfections = s.SameLevel
+	state.Stats.NextLevelInfections = s.NextLevel
+	state.Animals = s.Animals
+	for _, name := range s.Juveniles {
+		if a := state.Animals[name]; a != nil {
+			a.Juvenile = true
+		}
+	}
+	state.Dead = s.Dead
+	state.Births = s.Births
+
+	// Absent maps are saved as nothing; restore them empty so the run can write to them.
+	orEmpty := func(m map[string]int) map[string]int {
+		if m == nil {
+			return map[string]int{}
+		}
+		return m
+	}
+	state.BrokenPhases = orEmpty(s.BrokenPhases)
+	state.Cooldowns = orEmpty(s.Cooldowns)
+	state.FailStreak = orEmpty(s.FailStreak)
+	state.Latched = orEmpty(s.Latched)
+	state.Immune = orEmpty(s.Immune)
+	state.Upgrades = orEmpty(s.Upgrades)
+	if s.Wary != nil {
+		state.Wary = s.Wary
+	}
+	if s.AdviceDismissed != nil {
+		state.AdviceDismissed = s.AdviceDismissed
+	}
+	if s.Moods != nil {
+		state.Moods = s.Moods
+	}
+	if s.Vaccinated != nil {
+		state.Vaccinated = s.Vaccinated
+	}
+	if s.Priorities != nil {
+		state.Priorities = s.Priorities
+	}
+	if s.LastInteraction != nil {
+		state.LastInteraction = s.LastInteraction
+	}
+	if s.Genetics != nil {
+		state.Genetics = s.Genetics
+	}
+	state.Effects = s.Effects
+	state.MutationPoints = s.MutationPoints
+	state.BurstArmed = s.BurstArmed
+	state.BurstUsed = s.BurstUsed
+	state.MercyUsed = s.MercyUsed
+	state.DayReport = s.DayReport
+	state.Lineage = s.Lineage
+	state.Transmissions = s.Transmissions
+	state.Journal = s.Journal
+	state.Coop = s.PassAndPlay
+	state.Replay = s.Replay
+	state.Undo = s.Undo
+	state.Branches = s.Branches
+
+	state.Stats.StartTime = time.Now().Add(-time.Duration(s.GameSeconds * float64(time.Second)))
+	state.Stats.Clock.StartFrom(time.Duration(s.GameSeconds * float64(time.Second)))
+	engine.ResumeDraws(state, s.DrawsUsed)
+	return state, nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== SEED BROWSER =====
+//
+// The seed browser plays a batch of candidate seeds with the simulation bot and rates
+// each against the batch's median number of attempts. Ratings are cached per map,
+// ruleset and rules, so reopening the browser is instant until one of them changes.
+
+const seedBatchSize = 12
+
+type SeedRating struct {
+	Seed     int64  `json:"seed"`
+	Rating   string `json:"rating"`
+	Attempts int    `json:"attempts"`
+	Days     int    `json:"days"`
+	Won      bool   `json:"won"`
+}
+
+func seedCachePath() string {
+	return filepath.Join(dataDirs.Profile, "seeds.json")
+}
+
+// seedCacheKey identifies everything a rating depends on.
+func seedCacheKey(state *engine.GameState) string {
+	stamp := engine.CurrentStamp(state.MapPath)
+	rules, _ := json.Marshal(state.Rules)
+	return stamp.MapHash + "/" + stamp.RulesetHash + "/" + string(rules)
+}
+
+func loadSeedCache() map[string][]SeedRating {
+	cache := map[string][]SeedRating{}
+	data, err := os.ReadFile(seedCachePath())
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		slog.Warn("seed cache failed", "err", err)
+	}
+	return cache
+}
+
+func saveSeedRatings(key string, ratings []SeedRating) error {
+	if dataDirs.Profile == "" {
+		return fmt.Errorf("no profile directory")
+	}
+	cache := loadSeedCache()
+	cache[key] = ratings
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(seedCachePath(), data, 0o644)
+}
+
+// rateSeeds simulates each seed and rates it easy, medium or brutal. Losses and stalls
+// are always brutal.
+func rateSeeds(mapPath string, rules engine.RulesConfig, seeds []int64) []SeedRating {
+	var out []SeedRating
+	var attempts []int
+	for _, seed := range seeds {
+		run := simulateRun(mapPath, rules, seed)
+		out = append(out, SeedRating{Seed: seed, Attempts: run.Attempts, Days: run.Days, Won: run.Won})
+		if run.Won {
+			attempts = append(attempts, run.Attempts)
+		}
+	}
+	sort.Ints(attempts)
+	median := 0.0
+	if len(attempts) > 0 {
+		median = float64(attempts[len(attempts)/2])
+	}
+
+	for i := range out {
+		r := &out[i]
+		switch a := float64(r.Attempts); {
+		case !r.Won || a > 1.5*median:
+			r.Rating = "brutal"
+		case a < 0.75*median:
+			r.Rating = "easy"
+		default:
+			r.Rating = "medium"
+		}
+	}
+	return out
+}
+
+func ratingIcon(rating string) string {
+	switch rating {
+	case "easy":
+		return "🟢"
+	case "medium":
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
+
+// pickSeed starts over on state's map with seed, exactly as the browser simulated it.
+func pickSeed(state *engine.GameState, seed int64) *engine.GameState {
+	next := engine.NewGameState(state.MapPath)
+	next.Rules = state.Rules
+	engine.SeedRun(next, seed)
+	return next
+}
+
+func createSeedBrowserScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	key := seedCacheKey(state)
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("🎲 Choose a Seed"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	status := newLabel("")
+	list := container.NewVBox()
+	choose := func(seed int64) {
+		next := pickSeed(state, seed)
+		if err := engine.CheckStarters(next); err != nil {
+			win.SetContent(createMapErrorScreen(app, win, next, err))
+			return
+		}
+		win.SetContent(createStarterSelectionScreen(app, win, next))
+	}
+	inputs.HandlePrefix("seed:", func(arg string) {
+		if seed, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			choose(seed)
+		}
+	})
+
+	show := func(ratings []SeedRating) {
+		list.Objects = nil
+		for _, r := range ratings {
+			seed := r.Seed
+			outcome := fmt.Sprintf("won in %d attempts, %d days", r.Attempts, r.Days)
+			if !r.Won {
+				outcome = fmt.Sprintf("bot failed after %d attempts", r.Attempts)
+			}
+			list.Add(container.NewHBox(
+				newLabel(fmt.Sprintf("%s %-6s  seed %d — %s", ratingIcon(r.Rating), r.Rating, seed, outcome)),
+				layout.NewSpacer(),
+				newButton("Play", func() {
+					inputs.Record(fmt.Sprintf("seed:%d", seed))
+					choose(seed)
+				}),
+			))
+		}
+		list.Refresh()
+		status.SetText(fmt.Sprintf("%d seeds rated against a simple bot; your run may differ.", len(ratings)))
+	}
+
+	var reroll *widget.Button
+	simulate := func() {
+		reroll.Disable()
+		status.SetText(fmt.Sprintf("Simulating %d seeds…", seedBatchSize))
+		list.Objects = nil
+		list.Refresh()
+		base := time.Now().UnixNano()
+		mapPath, rules := state.MapPath, state.Rules
+		go func() {
+			seeds := make([]int64, seedBatchSize)
+			for i := range seeds {
+				seeds[i] = base + int64(i)
+			}
+			ratings := rateSeeds(mapPath, rules, seeds)
+			if err := saveSeedRatings(key, ratings); err != nil {
+				slog.Warn("seed cache failed", "err", err)
+			}
+			fyne.Do(func() {
+				show(ratings)
+				reroll.Enable()
+			})
+		}()
+	}
+	reroll = newButton("New batch", inputs.Bind("reroll-seeds", simulate))
+
+	if cached, ok := loadSeedCache()[key]; ok {
+		show(cached)
+	} else {
+		simulate()
+	}
+
+	header := container.NewBorder(nil, nil, back, reroll, title)
+	return NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewBorder(container.NewVBox(header, container.NewCenter(status)), nil, nil, nil, container.NewScroll(list))))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ===== SHUTDOWN =====
+
+// ShutdownManager runs cleanup hooks exactly once, whether the window is closed or the
+// process is interrupted, and cancels its context so background goroutines can exit.
+type ShutdownManager struct {
+	mu     sync.Mutex
+	hooks  []shutdownHook
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+type shutdownHook struct {
+	name string
+	fn   func()
+}
+
+func NewShutdownManager() *ShutdownManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ShutdownManager{ctx: ctx, cancel: cancel}
+}
+
+var shutdown = NewShutdownManager()
+
+// Context is cancelled as the first step of shutdown.
+func (m *ShutdownManager) Context() context.Context {
+	return m.ctx
+}
+
+// Done is a convenience for select statements in background loops.
+func (m *ShutdownManager) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// OnShutdown registers a cleanup hook. Hooks run in reverse registration order.
+func (m *ShutdownManager) OnShutdown(name string, fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, shutdownHook{name: name, fn: fn})
+}
+
+func (m *ShutdownManager) Shutdown() {
+	m.once.Do(func() {
+		m.cancel()
+
+		m.mu.Lock()
+		hooks := m.hooks
+		m.mu.Unlock()
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			m.runHook(hooks[i])
+		}
+	})
+}
+
+func (m *ShutdownManager) runHook(h shutdownHook) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("shutdown hook panicked", "hook", h.name, "panic", r)
+		}
+	}()
+	h.fn()
+}
+
+// HandleSignals stops the app on SIGINT/SIGTERM. The signal goroutine only cancels the
+// context and calls quit: the hooks stop audio, the dashboard and the log file, which
+// the UI goroutine owns, so quit must hand Shutdown to it.
+func (m *ShutdownManager) HandleSignals(quit func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigs:
+			m.cancel()
+			quit()
+		case <-m.ctx.Done():
+		}
+		signal.Stop(sigs)
+	}()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ===== ENTRY SIGNING =====
+//
+// Museum entries and the run history are the local record of runs and their scores.
+// Each entry is signed with a key made once per install, so an entry edited by hand in
+// museum.json or runs.json no longer verifies and is flagged. This keeps household competition honest; it cannot stop
+// someone who also has the key.
+
+func installKeyPath() string {
+	return filepath.Join(dataDirs.Profile, "install.key")
+}
+
+// installKey returns this install's signing key, creating it on first use.
+func installKey() ([]byte, error) {
+	if data, err := os.ReadFile(installKeyPath()); err == nil {
+		if key, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil && len(key) == 32 {
+			return key, nil
+		}
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(installKeyPath(), []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// signable is a signed record: a museum entry or a run of the run history.
+type signable interface {
+	// replayHash covers every field of the record but its signature.
+	replayHash() []byte
+	signature() string
+}
+
+// replayHash identifies everything needed to replay and score e: seed, rules, map,
+// tree, journal and the score itself.
+func (e MuseumEntry) replayHash() []byte {
+	e.Signature = ""
+	return hashJSON(e)
+}
+
+func (e MuseumEntry) signature() string { return e.Signature }
+
+// replayHash covers everything the run history and the high score table show of r.
+func (r RunRecord) replayHash() []byte {
+	r.Signature = ""
+	return hashJSON(r)
+}
+
+func (r RunRecord) signature() string { return r.Signature }
+
+func hashJSON(v any) []byte {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func signEntry(e signable) (string, error) {
+	key, err := installKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(e.replayHash())
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// verifyEntry reports whether e carries a valid signature from this install.
+func verifyEntry(e signable) bool {
+	if e.signature() == "" {
+		return false
+	}
+	want, err := signEntry(e)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(want), []byte(e.signature()))
+}
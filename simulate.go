@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== SIMULATION =====
+
+// simMaxSteps bounds a simulated run; a bot that has not finished by then is stalled.
+const simMaxSteps = 2000
+
+type SimRun struct {
+	Seed     int64  `json:"seed"`
+	Won      bool   `json:"won"`
+	Lost     bool   `json:"lost,omitempty"`
+	Stalled  bool   `json:"stalled,omitempty"`
+	Host     string `json:"host"`
+	Attempts int    `json:"attempts"`
+	Days     int    `json:"days"`
+	Score    int    `json:"score"`
+}
+
+type SimSummary struct {
+	Rules        engine.RulesConfig `json:"rules"`
+	Runs         []SimRun           `json:"runs"`
+	WinRate      float64            `json:"win_rate"`
+	MeanAttempts float64            `json:"mean_attempts"`
+	MeanDays     float64            `json:"mean_days"`
+	MeanScore    float64            `json:"mean_score"`
+}
+
+// botChoice is what a simple greedy player tries next: the likeliest available target
+// at the next level, else at the current one. It steers clear of red herrings it has
+// already run into. nil means it would rather wait.
+func botChoice(state *engine.GameState, knownHerrings map[string]bool) *engine.Animal {
+	host := state.Animals[state.PlayerName]
+	var best *engine.Animal
+	bestScore := -1.0
+	for _, a := range engine.CandidateTargets(state) {
+		if knownHerrings[a.Name] || engine.BlockedReason(state, a) != "" {
+			continue
+		}
+		score := engine.InfectionChance(state, a)
+		if a.Level > host.Level {
+			score += 1
+		}
+		if score > bestScore {
+			best, bestScore = a, score
+		}
+	}
+	return best
+}
+
+// botStarter is the bot's patient zero: the likeliest starter that is not a red herring.
+func botStarter(state *engine.GameState) *engine.Animal {
+	var starter *engine.Animal
+	for _, a := range engine.StarterCandidates(state) {
+		if !a.RedHerring && (starter == nil || a.InfectionRate > starter.InfectionRate) {
+			starter = a
+		}
+	}
+	return starter
+}
+
+// simulateRun plays one run with the bot from the likeliest starter.
+func simulateRun(mapPath string, rules engine.RulesConfig, seed int64) SimRun {
+	return simulateRunFrom(mapPath, rules, seed, "")
+}
+
+// simulateRunFrom plays one run with the bot from the starter named, or from the
+// likeliest one if starter is "".
+func simulateRunFrom(mapPath string, rules engine.RulesConfig, seed int64, starter string) SimRun {
+	state := engine.NewGameState(mapPath)
+	state.Rules = rules
+	engine.SeedRun(state, seed)
+	state.BotPlayed = true
+	run := SimRun{Seed: seed}
+
+	first := botStarter(state)
+	if starter != "" {
+		first = state.Animals[starter]
+	}
+	if first == nil || engine.ChooseStarter(state, first) != nil {
+		run.Stalled = true
+		return run
+	}
+
+	knownHerrings := map[string]bool{}
+	for step := 0; step < simMaxSteps && !run.Won && !run.Lost; step++ {
+		target := botChoice(state, knownHerrings)
+		if target == nil {
+			run.Won, run.Lost = engine.WaitOut(state)
+			continue
+		}
+		out := engine.AttemptInfection(state, target)
+		if out.Kind == engine.OutcomeRedHerring {
+			knownHerrings[target.Name] = true
+		}
+		run.Won, run.Lost = out.Won, out.Lost
+	}
+
+	run.Stalled = !run.Won && !run.Lost
+	run.Host = state.PlayerName
+	run.Attempts = state.Stats.Attempts
+	run.Days = state.CurrentDay
+	run.Score = engine.CalculateScore(state)
+	return run
+}
+
+// runSimulate implements the "simulate" subcommand:
+//
+//	simulate [--runs N] [--seed S] [--json] [rule flags]
+func runSimulate(args []string) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	rules := ruleFlags(fs)
+	setupLogging := logFlags(fs)
+	runs := fs.Int("runs", 100, "number of runs")
+	seed := fs.Int64("seed", 0, "seed of the first run; run i uses seed+i (default: time-based)")
+	mapFlag := fs.String("map", "", "map file to play (default: the user's copy, else the bundled map)")
+	asJSON := fs.Bool("json", false, "print every run as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	mapPath := initDataDirs()
+	setupLogging()
+	if *mapFlag != "" {
+		mapPath = *mapFlag
+	}
+	r, err := rules()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Simulate error:", err)
+		return 1
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	summary := SimSummary{Rules: r}
+	wins := 0
+	for i := 0; i < *runs; i++ {
+		run := simulateRun(mapPath, r, *seed+int64(i))
+		summary.Runs = append(summary.Runs, run)
+		summary.MeanAttempts += float64(run.Attempts)
+		summary.MeanDays += float64(run.Days)
+		if run.Won {
+			wins++
+			summary.MeanScore += float64(run.Score)
+		}
+	}
+	if *runs > 0 {
+		summary.WinRate = float64(wins) / float64(*runs)
+		summary.MeanAttempts /= float64(*runs)
+		summary.MeanDays /= float64(*runs)
+	}
+	if wins > 0 {
+		summary.MeanScore /= float64(wins)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(summary)
+		return 0
+	}
+	fmt.Printf("Runs:           %d (seeds %d…%d)\n", *runs, *seed, *seed+int64(*runs)-1)
+	fmt.Printf("Win rate:       %.1f%%\n", summary.WinRate*100)
+	fmt.Printf("Mean attempts:  %.1f\n", summary.MeanAttempts)
+	fmt.Printf("Mean days:      %.1f\n", summary.MeanDays)
+	fmt.Printf("Mean win score: %.0f\n", summary.MeanScore)
+	return 0
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== FILE NAME SLUGS =====
+
+// resolveAsset finds the file a map refers to: path itself, or its slugged form once
+// the file has been migrated.
+func resolveAsset(path string) string {
+	if path == "" || engine.FileExists(path) {
+		return path
+	}
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(filepath.Base(path), ext)
+	if s := filepath.Join(filepath.Dir(path), engine.Slug(stem)+strings.ToLower(ext)); engine.FileExists(s) {
+		return s
+	}
+	return path
+}
+
+// Rename is one file rename done, or planned, by the migration.
+type Rename struct {
+	From, To string
+}
+
+// slugRename plans renaming path to its slugged form. It is skipped when the name is
+// already a slug or the target is a different file that already exists; on a
+// case-insensitive filesystem the target may be path itself, which is still renamed.
+func slugRename(path string) (Rename, bool) {
+	if !engine.FileExists(path) {
+		return Rename{}, false
+	}
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(filepath.Base(path), ext)
+	to := filepath.Join(filepath.Dir(path), engine.Slug(stem)+strings.ToLower(ext))
+	if to == path {
+		return Rename{}, false
+	}
+	if info, err := os.Stat(to); err == nil {
+		if from, err := os.Stat(path); err != nil || !os.SameFile(info, from) {
+			return Rename{}, false
+		}
+	}
+	return Rename{path, to}, true
+}
+
+// planMigration lists the renames that move state's portraits and sounds to slugged
+// names.
+func planMigration(state *engine.GameState) []Rename {
+	var plan []Rename
+	seen := map[string]bool{}
+	add := func(path string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		if r, ok := slugRename(path); ok {
+			plan = append(plan, r)
+		}
+	}
+	for _, name := range engine.SortedAnimalNames(state) {
+		a := state.Animals[name]
+		add(filepath.Join(engine.ImageDir, a.SpeciesName()+".png"))
+		add(a.Sounds.Evolve)
+		add(a.Sounds.Resist)
+	}
+	return plan
+}
+
+// runMigrate implements "assets migrate":
+//
+//	assets migrate [--map file] [--dry-run]
+//
+// Maps keep referring to sounds by their old names; resolveAsset finds the renamed files.
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("assets migrate", flag.ContinueOnError)
+	mapFlag := fs.String("map", "", "map whose assets to rename (default: the user's copy, else the bundled map)")
+	dryRun := fs.Bool("dry-run", false, "list the renames without doing them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	mapPath := initDataDirs()
+	if *mapFlag != "" {
+		mapPath = *mapFlag
+	}
+	state := engine.NewGameState(mapPath)
+
+	plan := planMigration(state)
+	failed := 0
+	for _, r := range plan {
+		fmt.Printf("%s → %s\n", r.From, r.To)
+		if *dryRun {
+			continue
+		}
+		if err := os.Rename(r.From, r.To); err != nil {
+			fmt.Fprintln(os.Stderr, "Rename failed:", err)
+			failed++
+		}
+	}
+	switch {
+	case len(plan) == 0:
+		fmt.Println("Every asset already has a slugged name.")
+	case *dryRun:
+		fmt.Printf("%d file(s) would be renamed\n", len(plan))
+	default:
+		fmt.Printf("%d file(s) renamed\n", len(plan)-failed)
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"yellowstone_evolution/engine"
+)
+
+// ===== PASSIVE SPREAD =====
+
+// priorityControls is the header button that opens the priority target picker.
+func priorityControls(state *engine.GameState, win fyne.Window, redraw func()) fyne.CanvasObject {
+	toggle := func(name string) error {
+		a := state.Animals[name]
+		if a == nil {
+			return fmt.Errorf("no animal named %q", name)
+		}
+		return engine.TogglePriority(state, a)
+	}
+	inputs.HandlePrefix("priority:", func(name string) {
+		if toggle(name) == nil {
+			redraw()
+		}
+	})
+
+	return newButton(fmt.Sprintf("🎯 Priority targets (%d/%d)", len(state.Priorities), engine.PriorityLimit), func() {
+		state.Stats.Clock.Pause()
+		list := container.NewVBox()
+		var fill func()
+		fill = func() {
+			list.RemoveAll()
+			exposed := engine.ExposedAnimals(state)
+			if len(exposed) == 0 {
+				list.Add(newLabel("No animal has an infected contact to catch the virus from."))
+			}
+			for _, a := range exposed {
+				label := "Mark"
+				if state.Priorities[a.Name] {
+					label = "🎯 Unmark"
+				}
+				list.Add(container.NewBorder(nil, nil, nil,
+					newButton(label, func() {
+						inputs.Record("priority:" + a.Name)
+						if err := toggle(a.Name); err != nil {
+							dialog.ShowError(err, win)
+							return
+						}
+						fill()
+					}),
+					newLabel(fmt.Sprintf("%s (level %d) — %s tonight, from %s", a.Name, a.Level, engine.ChanceText(state, engine.SpreadChance(state, a)), engine.SpreadSource(state, a).Name))))
+			}
+			list.Refresh()
+		}
+		fill()
+
+		intro := newLabel(fmt.Sprintf("Mark up to %d animals; their spread chance is ×%.0f tonight. Marking any means no attempts until tomorrow.", engine.PriorityLimit, engine.PriorityBonus))
+		intro.Wrapping = fyne.TextWrapWord
+		d := dialog.NewCustom(plain("🎯 Priority Targets"), "Done", container.NewBorder(intro, nil, nil, nil, container.NewVScroll(list)), win)
+		d.Resize(fyne.NewSize(560, 420))
+		d.SetOnClosed(func() {
+			state.Stats.Clock.Resume()
+			redraw()
+		})
+		d.Show()
+	})
+}
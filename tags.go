@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== RUN TAGS AND STATS =====
+
+// parseTags splits comma-separated tags, lowercased and without duplicates.
+func parseTags(text string) []string {
+	var tags []string
+	for _, t := range strings.Split(text, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// tagRun replaces the tags of the run just finished, in the run history and, for a
+// win, in the museum. As in the museum, only a run that still verifies is signed again.
+func tagRun(state *engine.GameState, tags []string) error {
+	if state.RunID == 0 {
+		return fmt.Errorf("the run was not recorded")
+	}
+	runs := LoadRuns()
+	for i := range runs {
+		r := &runs[i]
+		if r.ID != state.RunID {
+			continue
+		}
+		r.Tags = tags
+		if r.Signature != "" && !r.Tampered {
+			sig, err := signEntry(*r)
+			if err != nil {
+				return err
+			}
+			r.Signature = sig
+		}
+	}
+	if err := saveRuns(runs); err != nil {
+		return err
+	}
+	return tagMuseumEntry(state.RunID, tags)
+}
+
+// tagControls lets the player tag the run on the win and loss screens.
+func tagControls(state *engine.GameState) fyne.CanvasObject {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Tags, e.g. challenge, stream")
+	saved := newLabel("")
+	apply := func(text string) {
+		if err := tagRun(state, parseTags(text)); err != nil {
+			saved.SetText(plain("⚠ " + err.Error()))
+			return
+		}
+		saved.SetText(plain("✓ Saved"))
+	}
+	save := newButton("🏷 Tag run", func() {
+		inputs.Record("tags:" + entry.Text)
+		apply(entry.Text)
+	})
+	inputs.HandlePrefix("tags:", func(text string) {
+		entry.SetText(text)
+		apply(text)
+	})
+
+	box := container.NewGridWrap(fyne.NewSize(280, entry.MinSize().Height), entry)
+	return container.NewHBox(box, save, saved)
+}
+
+// RunFilter selects runs on the stats screen; empty fields match everything.
+type RunFilter struct {
+	Tag, Map, Difficulty string
+	From, To             time.Time
+}
+
+// difficultyOf names a run's rules for filtering; runs without special rules are
+// "standard".
+func difficultyOf(r RunRecord) string {
+	if r.Rules == "" {
+		return "standard"
+	}
+	return r.Rules
+}
+
+func (f RunFilter) Match(r RunRecord) bool {
+	switch {
+	case f.Tag != "" && !slices.Contains(r.Tags, f.Tag):
+		return false
+	case f.Map != "" && r.Map != f.Map:
+		return false
+	case f.Difficulty != "" && difficultyOf(r) != f.Difficulty:
+		return false
+	case !f.From.IsZero() && r.Date.Before(f.From):
+		return false
+	case !f.To.IsZero() && !r.Date.Before(f.To.AddDate(0, 0, 1)):
+		return false
+	}
+	return true
+}
+
+func filterRuns(runs []RunRecord, f RunFilter) []RunRecord {
+	var out []RunRecord
+	for _, r := range runs {
+		if f.Match(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// distinct lists the non-empty values of key across runs, sorted.
+func distinct(runs []RunRecord, key func(RunRecord) []string) []string {
+	var out []string
+	for _, r := range runs {
+		for _, v := range key(r) {
+			if v != "" && !slices.Contains(out, v) {
+				out = append(out, v)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func runsSummary(runs []RunRecord) string {
+	if len(runs) == 0 {
+		return "No runs match."
+	}
+	wins, best, total := 0, 0, 0
+	for _, r := range runs {
+		if r.Won {
+			wins++
+			total += r.Score
+			best = max(best, r.Score)
+		}
+	}
+	line := fmt.Sprintf("%d runs — %.0f%% won", len(runs), float64(wins)*100/float64(len(runs)))
+	if wins > 0 {
+		line += fmt.Sprintf(" — mean win score %d — best %d", total/wins, best)
+	}
+	return line
+}
+
+const filterAll = "All"
+
+func createStatsScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
+
+	runs := LoadRuns()
+	slices.Reverse(runs)
+	var filter RunFilter
+	shown := runs
+
+	back := newButton("⬅ Back", inputs.Bind("back", func() {
+		win.SetContent(createIntroScreen(app, win, state))
+	}))
+	title := widget.NewLabelWithStyle(plain("📊 Run Statistics"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	summary := newLabel("")
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject { return newLabel("") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			r := shown[i]
+			result := "lost"
+			if r.Won {
+				result = fmt.Sprintf("won, score %d", r.Score)
+			}
+			line := fmt.Sprintf("%s — %s — %s — %s — %d attempts", r.Date.Format("2006-01-02 15:04"), r.Map, difficultyOf(r), result, r.Attempts)
+			if len(r.Tags) > 0 {
+				line += " — 🏷 " + strings.Join(r.Tags, ", ")
+			}
+			o.(*widget.Label).SetText(plain(line))
+		},
+	)
+	refresh := func() {
+		shown = filterRuns(runs, filter)
+		summary.SetText(runsSummary(shown))
+		list.Refresh()
+	}
+
+	// Each filter is recorded as "filter-<name>:<value>" so replays apply it too.
+	choice := func(name string, options []string, set func(string)) *widget.Select {
+		sel := widget.NewSelect(append([]string{filterAll}, options...), nil)
+		sel.SetSelected(filterAll)
+		sel.OnChanged = func(v string) {
+			inputs.Record("filter-" + name + ":" + v)
+			if v == filterAll {
+				v = ""
+			}
+			set(v)
+			refresh()
+		}
+		inputs.HandlePrefix("filter-"+name+":", func(v string) { sel.SetSelected(v) })
+		return sel
+	}
+	tag := choice("tag", distinct(runs, func(r RunRecord) []string { return r.Tags }), func(v string) { filter.Tag = v })
+	mapSel := choice("map", distinct(runs, func(r RunRecord) []string { return []string{r.Map} }), func(v string) { filter.Map = v })
+	difficulty := choice("difficulty", distinct(runs, func(r RunRecord) []string { return []string{difficultyOf(r)} }), func(v string) { filter.Difficulty = v })
+
+	date := func(name string, set func(time.Time)) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetPlaceHolder("YYYY-MM-DD")
+		e.OnSubmitted = func(v string) {
+			inputs.Record("filter-" + name + ":" + v)
+			d, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(v), time.Local)
+			if err != nil {
+				d = time.Time{}
+			}
+			set(d)
+			refresh()
+		}
+		inputs.HandlePrefix("filter-"+name+":", func(v string) {
+			e.SetText(v)
+			e.OnSubmitted(v)
+		})
+		return e
+	}
+	from := date("from", func(t time.Time) { filter.From = t })
+	to := date("to", func(t time.Time) { filter.To = t })
+
+	filters := container.NewGridWithColumns(5,
+		container.NewBorder(nil, nil, newLabel("Tag"), nil, tag),
+		container.NewBorder(nil, nil, newLabel("Map"), nil, mapSel),
+		container.NewBorder(nil, nil, newLabel("Rules"), nil, difficulty),
+		container.NewBorder(nil, nil, newLabel("From"), nil, from),
+		container.NewBorder(nil, nil, newLabel("To"), nil, to),
+	)
+	refresh()
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, back, exportButton(win, func() []RunRecord { return shown }), title),
+		filters,
+		container.NewCenter(summary),
+	)
+	return NewClickInterceptor(container.NewMax(loadBackground(), container.NewBorder(header, nil, nil, nil, list)))
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/driver/mobile"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== CARD TOOLTIPS =====
+
+const longPressDelay = 500 * time.Millisecond
+
+// tooltipOffset keeps the popup clear of the pointer so it doesn't steal the hover.
+var tooltipOffset = fyne.NewPos(16, 16)
+
+// InfoCard wraps a card and shows extra detail on hover (desktop) or long press (mobile).
+type InfoCard struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	win     fyne.Window
+	info    func() string
+
+	popup *widget.PopUp
+	press *time.Timer
+}
+
+func NewInfoCard(content fyne.CanvasObject, win fyne.Window, info func() string) *InfoCard {
+	c := &InfoCard{content: content, win: win, info: info}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *InfoCard) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
+
+func (c *InfoCard) show(pos fyne.Position) {
+	if c.popup == nil {
+		c.popup = widget.NewPopUp(newLabel(""), c.win.Canvas())
+	}
+	c.popup.Content.(*widget.Label).SetText(c.info())
+	c.popup.ShowAtPosition(pos.Add(tooltipOffset))
+}
+
+func (c *InfoCard) hide() {
+	if c.popup != nil {
+		c.popup.Hide()
+	}
+}
+
+func (c *InfoCard) MouseIn(ev *desktop.MouseEvent) {
+	c.show(ev.AbsolutePosition)
+}
+
+func (c *InfoCard) MouseMoved(ev *desktop.MouseEvent) {
+	if c.popup != nil && c.popup.Visible() {
+		c.popup.Move(ev.AbsolutePosition.Add(tooltipOffset))
+	}
+}
+
+func (c *InfoCard) MouseOut() {
+	c.hide()
+}
+
+func (c *InfoCard) TouchDown(ev *mobile.TouchEvent) {
+	pos := ev.AbsolutePosition
+	c.press = time.AfterFunc(longPressDelay, func() {
+		fyne.Do(func() { c.show(pos) })
+	})
+}
+
+func (c *InfoCard) TouchUp(*mobile.TouchEvent) {
+	c.cancelPress()
+}
+
+func (c *InfoCard) TouchCancel(*mobile.TouchEvent) {
+	c.cancelPress()
+}
+
+func (c *InfoCard) cancelPress() {
+	if c.press != nil {
+		c.press.Stop()
+		c.press = nil
+	}
+}
+
+// cardInfo is the tooltip text for a target: chance breakdown, contacts and the
+// last time the player tried it.
+func cardInfo(state *engine.GameState, a *engine.Animal) string {
+	var b strings.Builder
+	if engine.Blinded(state) {
+		b.WriteString("Chance: hidden by a curse\n")
+	} else {
+		b.WriteString(chanceBreakdown(state, a))
+	}
+
+	if note := engine.GeneticsNote(state, a); note != "" {
+		b.WriteString(note + "\n")
+	}
+
+	if len(a.Contacts) > 0 {
+		var parts []string
+		for _, c := range a.Contacts {
+			part := c.Name
+			if c.Kind != "" {
+				part += " (" + c.Kind + ")"
+			}
+			if c.Weight != 1 {
+				part += fmt.Sprintf(" ×%.2f", c.Weight)
+			}
+			parts = append(parts, part)
+		}
+		fmt.Fprintf(&b, "Contacts: %s\n", strings.Join(parts, ", "))
+	} else {
+		b.WriteString("Contacts: none recorded\n")
+	}
+
+	if last, ok := engine.KnownInteraction(state, a.Name); ok {
+		fmt.Fprintf(&b, "Last interaction: %s", last)
+	} else {
+		b.WriteString("Last interaction: never")
+	}
+	return b.String()
+}
+
+// chanceBreakdown explains how a's chance is made up, and its crit odds.
+func chanceBreakdown(state *engine.GameState, a *engine.Animal) string {
+	season := engine.SeasonForDay(state.CurrentDay)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Chance: %.0f%% base", a.InfectionRate*100)
+	if m := a.SeasonalBehavior(season).RateMultiplier; m != 0 && m != 1 {
+		fmt.Fprintf(&b, " × %.2f %s", m, season)
+	}
+	fmt.Fprintf(&b, " × %.2f strength", engine.VirusStrength(state))
+	if f := engine.MoodFactor(state, a); f != 1 {
+		fmt.Fprintf(&b, " × %.2f %s", f, engine.MoodOf(state, a))
+	}
+	if f := engine.ContactFactor(state, a); f != 1 {
+		fmt.Fprintf(&b, " × %.2f contacts", f)
+	}
+	if f := engine.SymbioteFactor(state, a); f != 1 {
+		fmt.Fprintf(&b, " × %.2f symbiote", f)
+	}
+	fmt.Fprintf(&b, " = %.0f%%", engine.BaseChance(state, a)*100)
+	if base, final := engine.BaseChance(state, a), engine.InfectionChance(state, a); final != base {
+		fmt.Fprintf(&b, " → %.0f%% with map modifiers", final*100)
+		if state.BurstArmed {
+			b.WriteString(" and the mutation burst")
+		}
+	}
+	b.WriteString("\n")
+	if s, f := engine.CritOdds(state, a); s > 0 || f > 0 {
+		fmt.Fprintf(&b, "Crits: %.1f%% critical success (infects a contact too), %.1f%% critical failure\n", s*100, f*100)
+	}
+	return b.String()
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"yellowstone_evolution/engine"
+)
+
+// ===== UPDATES =====
+//
+// Updates come from the GitHub releases of the project. A release is newer when its tag
+// (e.g. "v0.10.0") is a higher version than EngineVersion. Its assets may include a
+// binary named rawr-<os>-<arch> (".exe" on Windows), which replaces the running one, and
+// map files (".json"), which replace the sample maps in the user's maps directory. A
+// replaced map is kept next to the new one as <name>.bak. Nothing is installed unless
+// the release publishes a checksums.txt and every file matches its SHA-256 sum.
+
+const (
+	releasesURL    = "https://api.github.com/repos/anaymody/rAAwr/releases/latest"
+	checksumsAsset = "checksums.txt"
+	updateTimeout  = 60 * time.Second
+)
+
+type ReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+type Release struct {
+	Tag    string         `json:"tag_name"`
+	Name   string         `json:"name"`
+	Body   string         `json:"body"`
+	Assets []ReleaseAsset `json:"assets"`
+}
+
+// Version is the tag without its leading "v".
+func (r Release) Version() string { return strings.TrimPrefix(r.Tag, "v") }
+
+func (r Release) binaryAsset() (ReleaseAsset, bool) {
+	name := fmt.Sprintf("rawr-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return ReleaseAsset{}, false
+}
+
+func (r Release) mapAssets() []ReleaseAsset {
+	var out []ReleaseAsset
+	for _, a := range r.Assets {
+		if strings.HasSuffix(a.Name, ".json") {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// newerVersion reports whether a is a higher dotted version than b. Missing or
+// non-numeric parts count as 0.
+func newerVersion(a, b string) bool {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+var updateClient = &http.Client{Timeout: updateTimeout}
+
+// latestRelease fetches the newest release; ok is false when it is not newer than
+// this build.
+func latestRelease() (rel Release, ok bool, err error) {
+	resp, err := updateClient.Get(releasesURL)
+	if err != nil {
+		return rel, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return rel, false, fmt.Errorf("release feed answered %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return rel, false, err
+	}
+	return rel, newerVersion(rel.Version(), engine.EngineVersion), nil
+}
+
+// releaseChecksums fetches rel's checksums.txt, in the format sha256sum writes, and
+// returns the SHA-256 sum of each asset by name. A release without one is not installed.
+func releaseChecksums(rel Release) (map[string]string, error) {
+	var url string
+	for _, a := range rel.Assets {
+		if a.Name == checksumsAsset {
+			url = a.URL
+		}
+	}
+	if url == "" {
+		return nil, fmt.Errorf("release %s publishes no %s", rel.Version(), checksumsAsset)
+	}
+	resp, err := updateClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", checksumsAsset, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	sums := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+	}
+	return sums, nil
+}
+
+// fetch downloads url into a temporary file next to path and checks it against sum,
+// the published SHA-256. It returns the temporary file, which the caller renames into
+// place; on any error nothing is left behind.
+func fetch(url, path, sum string, mode os.FileMode) (string, error) {
+	if sum == "" {
+		return "", fmt.Errorf("%s: no published checksum", filepath.Base(path))
+	}
+	resp, err := updateClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", filepath.Base(path), resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return "", err
+	}
+	fail := func(err error) (string, error) {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		return fail(err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != sum {
+		return fail(fmt.Errorf("%s: checksum mismatch (got %s, want %s)", filepath.Base(path), got, sum))
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fail(err)
+	}
+	return tmp.Name(), nil
+}
+
+// download installs url at path once it has been fetched and checked, so a failed or
+// tampered download never replaces the file.
+func download(url, path, sum string, mode os.FileMode) error {
+	tmp, err := fetch(url, path, sum, mode)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// applyUpdate installs rel's maps and binary, and describes what it changed. The new
+// binary takes effect on the next launch.
+func applyUpdate(rel Release) ([]string, error) {
+	sums, err := releaseChecksums(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	if dataDirs.Maps != "" {
+		for _, a := range rel.mapAssets() {
+			// The asset name comes from the network; only its last element is used.
+			name := filepath.Base(a.Name)
+			dst := filepath.Join(dataDirs.Maps, name)
+			if engine.FileExists(dst) {
+				if err := copyFile(dst, dst+".bak"); err != nil {
+					return done, err
+				}
+			}
+			if err := download(a.URL, dst, sums[a.Name], 0o644); err != nil {
+				return done, err
+			}
+			done = append(done, "map "+name)
+		}
+	}
+
+	if a, ok := rel.binaryAsset(); ok {
+		exe, err := os.Executable()
+		if err != nil {
+			return done, err
+		}
+		if exe, err = filepath.EvalSymlinks(exe); err != nil {
+			return done, err
+		}
+		tmp, err := fetch(a.URL, exe, sums[a.Name], 0o755)
+		if err != nil {
+			return done, err
+		}
+		if runtime.GOOS == "windows" {
+			// A running executable cannot be replaced on Windows, but it can be renamed.
+			_ = os.Remove(exe + ".old")
+			if err := os.Rename(exe, exe+".old"); err != nil {
+				os.Remove(tmp)
+				return done, err
+			}
+		}
+		if err := os.Rename(tmp, exe); err != nil {
+			os.Remove(tmp)
+			if runtime.GOOS == "windows" {
+				_ = os.Rename(exe+".old", exe)
+			}
+			return done, err
+		}
+		done = append(done, "game "+rel.Version())
+	}
+	return done, nil
+}
+
+// checkForUpdates is the Help menu item: it shows the changelog of a newer release and
+// installs it if the player agrees.
+func checkForUpdates(win fyne.Window) {
+	progress := dialog.NewCustomWithoutButtons("Checking for updates…", widget.NewProgressBarInfinite(), win)
+	progress.Show()
+
+	go func() {
+		rel, newer, err := latestRelease()
+		fyne.Do(func() {
+			progress.Hide()
+			switch {
+			case err != nil:
+				dialog.ShowError(err, win)
+			case !newer:
+				dialog.ShowInformation("Up to Date", "You have the latest version ("+engine.EngineVersion+").", win)
+			default:
+				confirmUpdate(rel, win)
+			}
+		})
+	}()
+}
+
+func confirmUpdate(rel Release, win fyne.Window) {
+	changelog := widget.NewRichTextFromMarkdown(rel.Body)
+	changelog.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(changelog)
+	scroll.SetMinSize(fyne.NewSize(480, 300))
+
+	title := fmt.Sprintf("Update to %s (you have %s)", rel.Version(), engine.EngineVersion)
+	dialog.ShowCustomConfirm(title, "Update", "Not now", scroll, func(yes bool) {
+		if !yes {
+			return
+		}
+		go func() {
+			done, err := applyUpdate(rel)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				dialog.ShowInformation("Updated", "Installed: "+strings.Join(done, ", ")+".\nRestart the game to use the new version.", win)
+			})
+		}()
+	}, win)
+}
+
+// runUpdate implements the "update" subcommand:
+//
+//	update [--check]
+func runUpdate(args []string) int {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	check := fs.Bool("check", false, "only report whether an update is available")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	initDataDirs()
+
+	rel, newer, err := latestRelease()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Update error:", err)
+		return 1
+	}
+	if !newer {
+		fmt.Println("Up to date:", engine.EngineVersion)
+		return 0
+	}
+	fmt.Printf("Update available: %s (you have %s)\n\n%s\n", rel.Version(), engine.EngineVersion, rel.Body)
+	if *check {
+		return 0
+	}
+	done, err := applyUpdate(rel)
+	for _, d := range done {
+		fmt.Println("Installed", d)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Update error:", err)
+		return 1
+	}
+	return 0
+}
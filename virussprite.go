@@ -0,0 +1,117 @@
+package main
+
+import (
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"github.com/anthonynsimon/bild/blend"
+	"github.com/anthonynsimon/bild/blur"
+	"yellowstone_evolution/engine"
+)
+
+// ===== VIRUS SPRITE =====
+//
+// The header shows a small virus drawn from the run itself: the body and its inner
+// segments grow with the host's level, and every virus mode adds a ring of spikes in its
+// own colour. The shape depends only on the level and the modes, so it never flickers
+// between redraws.
+
+const virusSpriteSize = 96
+
+// virusSprite draws the virus for a host at level with the given modes.
+func virusSprite(level int, modes []string) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, virusSpriteSize, virusSpriteSize))
+	c := float64(virusSpriteSize) / 2
+
+	body := math.Min(12+3*float64(level), 26)
+	spikes := 6 + 3*len(modes)
+	spikeLen := math.Min(6+2*float64(level), c-body-4)
+	segments := level
+
+	tint := color.NRGBA{R: 0x60, G: 0xd0, B: 0x70, A: 0xff}
+	spikeColor := func(i int) color.NRGBA {
+		if len(modes) == 0 {
+			return tint
+		}
+		return modeColor(modes[i%len(modes)])
+	}
+
+	for y := 0; y < virusSpriteSize; y++ {
+		for x := 0; x < virusSpriteSize; x++ {
+			dx, dy := float64(x)+0.5-c, float64(y)+0.5-c
+			r := math.Hypot(dx, dy)
+			theta := math.Atan2(dy, dx)
+
+			if r <= body {
+				// Darker bands mark one segment per level.
+				shade := 1.0
+				if segments > 0 && int(r/body*float64(segments+1))%2 == 1 {
+					shade = 0.75
+				}
+				img.Set(x, y, color.NRGBA{
+					R: uint8(float64(tint.R) * shade), G: uint8(float64(tint.G) * shade),
+					B: uint8(float64(tint.B) * shade), A: 0xff,
+				})
+				continue
+			}
+
+			step := 2 * math.Pi / float64(spikes)
+			i := int(math.Round(theta/step)) % spikes
+			if i < 0 {
+				i += spikes
+			}
+			off := math.Abs(math.Remainder(theta, step)) * r
+			switch {
+			case r <= body+spikeLen && off <= 1.5:
+				img.Set(x, y, spikeColor(i))
+			case math.Abs(r-body-spikeLen) <= 2.5 && off <= 2.5:
+				img.Set(x, y, spikeColor(i))
+			}
+		}
+	}
+
+	// A soft glow under the sharp sprite.
+	return blend.Normal(blur.Gaussian(img, 3), img)
+}
+
+// modeColor gives every virus mode a stable, bright colour.
+func modeColor(mode string) color.NRGBA {
+	h := fnv.New32a()
+	h.Write([]byte(mode))
+	hue := float64(h.Sum32()%360) / 60
+	x := uint8(255 * (1 - math.Abs(math.Mod(hue, 2)-1)))
+	switch int(hue) {
+	case 0:
+		return color.NRGBA{R: 255, G: x, A: 0xff}
+	case 1:
+		return color.NRGBA{R: x, G: 255, A: 0xff}
+	case 2:
+		return color.NRGBA{G: 255, B: x, A: 0xff}
+	case 3:
+		return color.NRGBA{G: x, B: 255, A: 0xff}
+	case 4:
+		return color.NRGBA{R: x, B: 255, A: 0xff}
+	default:
+		return color.NRGBA{R: 255, B: x, A: 0xff}
+	}
+}
+
+// virusSpriteImage is the header widget for state's current virus.
+func virusSpriteImage(state *engine.GameState) fyne.CanvasObject {
+	if liteMode {
+		return container.NewWithoutLayout()
+	}
+	level := 0
+	if host := state.Animals[state.PlayerName]; host != nil {
+		level = host.Level
+	}
+	img := canvas.NewImageFromImage(virusSprite(level, state.Virus.Modes))
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(virusSpriteSize/2, virusSpriteSize/2))
+	return img
+}
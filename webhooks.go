@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"yellowstone_evolution/engine"
+)
+
+// ===== GAME EVENT WEBHOOKS =====
+//
+// webhooks.json in the config directory lists URLs that get a JSON POST when a run
+// starts, the virus evolves, or the run is won or lost:
+//
+//	{"hooks": [{"url": "https://hooks.slack.com/services/…", "events": ["won", "lost"]}]}
+//
+// A hook with no events gets all of them. Every payload has a "text" line, so a Slack
+// incoming webhook can take it as is. Runs the bot plays never call the hooks.
+
+const webhooksConfigPath = "webhooks.json"
+
+// Webhook event names, as used in webhooks.json and in payloads.
+const (
+	HookStarted   = "started"
+	HookEvolution = "evolution"
+	HookWon       = "won"
+	HookLost      = "lost"
+)
+
+// webhookQueue is how many payloads may wait for delivery before new ones are dropped.
+const webhookQueue = 32
+
+type WebhookConfig struct {
+	Hooks []Webhook `json:"hooks"`
+}
+
+type Webhook struct {
+	URL string `json:"url"`
+	// Events are the event names to send; empty means all.
+	Events []string `json:"events"`
+}
+
+func (h Webhook) wants(event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, event)
+}
+
+// WebhookPayload is the JSON body posted for an event.
+type WebhookPayload struct {
+	Event   string    `json:"event"`
+	Animal  string    `json:"animal"`
+	Score   int       `json:"score,omitempty"`
+	Student string    `json:"student,omitempty"`
+	Time    time.Time `json:"time"`
+	Text    string    `json:"text"`
+}
+
+func LoadWebhookConfig() WebhookConfig {
+	var cfg WebhookConfig
+	if dataDirs.Config == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(filepath.Join(dataDirs.Config, webhooksConfigPath))
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		slog.Warn("webhook config unreadable", "err", err)
+	}
+	return cfg
+}
+
+// webhookPayload turns a bus event into a payload, or returns false for events no hook
+// is told about.
+func webhookPayload(ev engine.Event) (WebhookPayload, bool) {
+	p := WebhookPayload{Animal: ev.Detail, Time: time.Now()}
+	switch {
+	case ev.Bot:
+		return p, false
+	case ev.Kind == engine.EventRunStarted:
+		p.Event, p.Text = HookStarted, fmt.Sprintf("🦠 A new outbreak started in %s.", ev.Detail)
+	case ev.Kind == engine.EventOutcome && ev.Magnitude == engine.MagnitudeEvolution:
+		p.Event, p.Text = HookEvolution, fmt.Sprintf("🧬 The virus evolved into %s.", ev.Detail)
+	case ev.Kind == engine.EventRunEnded && ev.Won:
+		p.Event, p.Text = HookWon, fmt.Sprintf("👑 The outbreak won in %s with %d points.", ev.Detail, ev.Score)
+		p.Score = ev.Score
+	case ev.Kind == engine.EventRunEnded:
+		p.Event, p.Text = HookLost, fmt.Sprintf("💀 The outbreak ran out of attempts in %s.", ev.Detail)
+		p.Score = ev.Score
+	default:
+		return p, false
+	}
+	return p, true
+}
+
+// attachWebhooks subscribes the configured hooks to the bus. Payloads are posted in
+// order by one worker, so a slow endpoint never holds up the game.
+func attachWebhooks() {
+	cfg := LoadWebhookConfig()
+	if len(cfg.Hooks) == 0 {
+		return
+	}
+	student := LoadExportConfig().Student
+	queue := make(chan WebhookPayload, webhookQueue)
+
+	go func() {
+		for {
+			select {
+			case <-shutdown.Done():
+				return
+			case p := <-queue:
+				for _, h := range cfg.Hooks {
+					if !h.wants(p.Event) {
+						continue
+					}
+					if err := postJSON(h.URL, p); err != nil {
+						slog.Warn("webhook failed", "url", h.URL, "err", err)
+					}
+				}
+			}
+		}
+	}()
+
+	send := func(ev engine.Event) {
+		p, ok := webhookPayload(ev)
+		if !ok {
+			return
+		}
+		p.Student = student
+		select {
+		case queue <- p:
+		default:
+			slog.Warn("webhook queue full, event dropped", "event", p.Event)
+		}
+	}
+	for _, kind := range []string{engine.EventRunStarted, engine.EventOutcome, engine.EventRunEnded} {
+		engine.Events.Subscribe(kind, send)
+	}
+}
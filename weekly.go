@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"yellowstone_evolution/engine"
+)
+
+// ===== WEEKLY MUTATION =====
+//
+// Each ISO week has a mutation: one extra chance modifier, in the language of map
+// modifiers, applied on top of the map's own. weekly.json in the config directory may
+// name a feed, a JSON list of mutations with their weeks:
+//
+//	[{"week": "2026-W42", "name": "Grounded", "description": "…", "modifier": "if target.Mobility == 'Fly' then chance*0.8"}]
+//
+// Without a feed, or for a week the feed does not list, the mutation is picked from
+// WeeklyMutations by a hash of the week, so everyone gets the same one.
+//
+// A run that plays the mutation keeps a copy of it in its rules, which is what the
+// weekly board compares: runs only rank against runs with the same mutation.
+
+const (
+	weeklyConfigPath = "weekly.json"
+	weeklyTimeout    = 5 * time.Second
+)
+
+type WeeklyConfig struct {
+	// Feed, if set, is fetched at startup for the mutations of coming weeks.
+	Feed string `json:"feed"`
+}
+
+func LoadWeeklyConfig() WeeklyConfig {
+	var cfg WeeklyConfig
+	if dataDirs.Config == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(filepath.Join(dataDirs.Config, weeklyConfigPath))
+	if err != nil {
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		slog.Warn("weekly config unreadable", "err", err)
+	}
+	return cfg
+}
+
+// isoWeek names the ISO week of t, e.g. "2026-W42".
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// weeklyFeed holds the fetched feed; it is read by the UI and written once at startup.
+var weeklyFeed struct {
+	sync.Mutex
+	list []engine.WeeklyMutation
+}
+
+// loadWeeklyFeed fetches the configured feed, keeping the entries whose modifiers
+// compile. It does nothing without a feed.
+func loadWeeklyFeed() {
+	cfg := LoadWeeklyConfig()
+	if cfg.Feed == "" {
+		return
+	}
+	list, err := fetchWeeklyFeed(cfg.Feed)
+	if err != nil {
+		slog.Warn("weekly feed unavailable", "feed", cfg.Feed, "err", err)
+		return
+	}
+	var ok []engine.WeeklyMutation
+	for _, w := range list {
+		if _, err := engine.CompileModifier(w.Modifier); err != nil {
+			slog.Warn("weekly mutation skipped", "week", w.Week, "err", err)
+			continue
+		}
+		ok = append(ok, w)
+	}
+	weeklyFeed.Lock()
+	weeklyFeed.list = ok
+	weeklyFeed.Unlock()
+}
+
+func fetchWeeklyFeed(url string) ([]engine.WeeklyMutation, error) {
+	client := &http.Client{Timeout: weeklyTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed answered %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var list []engine.WeeklyMutation
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// weeklyFor is the mutation of the week containing t.
+func weeklyFor(t time.Time) engine.WeeklyMutation {
+	week := isoWeek(t)
+	weeklyFeed.Lock()
+	defer weeklyFeed.Unlock()
+	for _, w := range weeklyFeed.list {
+		if w.Week == week {
+			return w
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(week))
+	w := engine.WeeklyMutations[h.Sum32()%uint32(len(engine.WeeklyMutations))]
+	w.Week = week
+	return w
+}
+
+// weeklyBoardSize is how many runs the weekly board lists.
+const weeklyBoardSize = 10
+
+// weeklyBoard lists the best won runs with mutation w, highest score first, leaving
+// out tampered runs.
+func weeklyBoard(runs []RunRecord, w engine.WeeklyMutation) []RunRecord {
+	var out []RunRecord
+	for _, r := range runs {
+		if r.Won && !r.Tampered && r.Ruleset != nil && r.Ruleset.Weekly == w && !r.Ruleset.Practice {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > weeklyBoardSize {
+		out = out[:weeklyBoardSize]
+	}
+	return out
+}
+
+// showWeeklyBoard lists this week's best runs.
+func showWeeklyBoard(w engine.WeeklyMutation, win fyne.Window) {
+	list := container.NewVBox(newLabel(w.Description))
+	board := weeklyBoard(LoadRuns(), w)
+	if len(board) == 0 {
+		list.Add(newLabel("No wins with this mutation yet."))
+	}
+	for i, r := range board {
+		line := fmt.Sprintf("%d. %d — %s, day %d, %d attempts", i+1, r.Score, r.Host, r.Day, r.Attempts)
+		if r.Student != "" {
+			line += " — " + r.Student
+		}
+		list.Add(newLabel(line))
+	}
+	dialog.ShowCustom(plain("🏆 Weekly Board — "+w.Label()), "Close", list, win)
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
 	"image/color"
-	"io/ioutil"
-	"math/rand"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,16 +15,13 @@ import (
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
-	"github.com/anthonynsimon/bild/effect"
-	"github.com/anthonynsimon/bild/imgio"
-
-	// Audio
 	"github.com/faiface/beep"
 	"github.com/faiface/beep/mp3"
 	"github.com/faiface/beep/speaker"
+	"yellowstone_evolution/engine"
 )
 
 // ===== AUDIO =====
@@ -32,7 +30,7 @@ var musicCtrl *beep.Ctrl
 var musicPlaying bool
 
 func PlayMusicLoop(path string) error {
-	if musicPlaying {
+	if musicPlaying || liteMode {
 		return nil
 	}
 
@@ -49,29 +47,27 @@ func PlayMusicLoop(path string) error {
 	loop := beep.Loop(-1, streamer)
 
 	speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10))
+	audio.SetSampleRate(format.SampleRate)
 	musicCtrl = &beep.Ctrl{Streamer: loop, Paused: false}
 	speaker.Play(musicCtrl)
 
 	musicPlaying = true
+	engine.Events.Publish(engine.Event{Kind: engine.EventSound, Detail: path})
 	return nil
 }
 
-func PlaySoundEffect(path string) {
-	f, err := os.Open(path)
-	if err != nil {
-		fmt.Println("SFX error:", err)
-		return
-	}
-
-	streamer, _, err := mp3.Decode(f)
-	if err != nil {
-		fmt.Println("SFX decode error:", err)
-		return
+// StopAudio silences everything and releases the audio device.
+func StopAudio() {
+	speaker.Clear()
+	if musicPlaying {
+		speaker.Close()
+		musicPlaying = false
 	}
+}
 
-	speaker.Play(beep.Seq(streamer, beep.Callback(func() {
-		_ = f.Close()
-	})))
+func PlaySoundEffect(path string) {
+	audio.Play(path)
+	engine.Events.Publish(engine.Event{Kind: engine.EventSound, Detail: path})
 }
 
 // ===== UNIVERSAL CLICK INTERCEPTOR =====
@@ -82,7 +78,7 @@ type ClickInterceptor struct {
 }
 
 func NewClickInterceptor(content fyne.CanvasObject) *ClickInterceptor {
-	c := &ClickInterceptor{content: content}
+	c := &ClickInterceptor{content: devHUD.layer(content)}
 	c.ExtendBaseWidget(c)
 	return c
 }
@@ -95,122 +91,46 @@ func (c *ClickInterceptor) MouseDown(*fyne.PointEvent) {
 	PlaySoundEffect("sfx/click.mp3")
 }
 
-// ===== GAME DATA =====
-
-type Animal struct {
-	Name          string   `json:"Name"`
-	Level         int      `json:"Level"`
-	Mobility      string   `json:"Mobility"`
-	Intelligence  int      `json:"Intelligence"`
-	Contacts      []string `json:"Contacts"`
-	Infected      bool     `json:"Infected"`
-	InfectionRate float64  `json:"InfectionRate"`
-	Location      string   `json:"Location"`
-	RedHerring    bool     `json:"RedHerring"`
-}
-
-func (a *Animal) GetImagePath() string {
-	return fmt.Sprintf("png/%s.png", a.Name)
-}
-
-type Virus struct {
-	Modes    []string
-	Strength float64
-}
-
-type RedHerringInfo struct {
-	FunFact string `json:"FunFact"`
-	Reason  string `json:"Reason"`
-}
-
-type Stats struct {
-	Attempts            int
-	SameLevelInfections int
-	NextLevelInfections int
-	StartTime           time.Time
-}
-
-type GameState struct {
-	animals    map[string]*Animal
-	playerName string
-	maxLevel   int
-	currentDay int
-	virus      *Virus
-	stats      Stats
-	timerStop  chan bool
-	redFacts   map[string]RedHerringInfo
-	score      int
-}
-
-// ===== LOADING =====
-
-func LoadAnimalsFromJSON(path string) (map[string]*Animal, int) {
-	data, _ := ioutil.ReadFile(path)
-
-	var raw map[string][]*Animal
-	json.Unmarshal(data, &raw)
-
-	result := map[string]*Animal{}
-	max := 0
-	for _, arr := range raw {
-		for _, a := range arr {
-			result[a.Name] = a
-			if a.Level > max {
-				max = a.Level
-			}
-		}
-	}
-	return result, max
-}
-
-func LoadRedHerringFacts(path string) map[string]RedHerringInfo {
-	data, err := ioutil.ReadFile(path)
-	if err != nil {
-		return map[string]RedHerringInfo{}
-	}
-	var out map[string]RedHerringInfo
-	_ = json.Unmarshal(data, &out)
-	return out
-}
-
 // ===== UI HELPERS =====
 
-func loadBackground() *canvas.Image {
-	bg := canvas.NewImageFromFile("yellowstone.png")
+func loadBackground() fyne.CanvasObject {
+	if liteMode {
+		return canvas.NewRectangle(theme.Color(theme.ColorNameBackground))
+	}
+	bg := canvas.NewImageFromFile(backgroundPath)
 	bg.FillMode = canvas.ImageFillStretch
-	return bg
+	return NewAmbientBackground(bg)
 }
 
-func loadAnimalImage(path string, invert bool, size float32) *canvas.Image {
-	img, err := imgio.Open(path)
+func loadAnimalImage(path string, size float32, filters ...ImageFilter) *canvas.Image {
+	img, err := images.Get(path, filters...)
 	if err != nil {
 		return canvas.NewImageFromImage(nil)
 	}
-	if invert {
-		img = effect.Invert(img)
-	}
 	i := canvas.NewImageFromImage(img)
 	i.SetMinSize(fyne.NewSize(size, size))
 	i.FillMode = canvas.ImageFillContain
 	return i
 }
 
-// ===== SCORE =====
-
-func calculateScore(state *GameState) int {
-	secs := int(time.Since(state.stats.StartTime).Seconds())
-	score := 1000 + (state.stats.NextLevelInfections * 200) - (state.stats.SameLevelInfections * 100) - (state.stats.Attempts * 10) - secs/2
-	if score < 0 {
-		score = 0
+// statusFilters marks a portrait with what keeps the virus out of the animal.
+func statusFilters(state *engine.GameState, a *engine.Animal) []ImageFilter {
+	switch {
+	case state.Vaccinated[a.Name]:
+		return []ImageFilter{FilterRedTint}
+	case engine.IsImmune(state, a):
+		return []ImageFilter{FilterSepia}
 	}
-	return score
+	return nil
 }
 
 // ===== ANIMATION =====
 
-func showSpookyAnimation(win fyne.Window, state *GameState, imgPath, name string, after func()) {
+func showSpookyAnimation(win fyne.Window, state *engine.GameState, imgPath, name string, after func()) {
+	inputs.Reset()
+	redrawScreen = nil
 	bg := loadBackground()
-	img := loadAnimalImage(imgPath, true, 430)
+	img := loadAnimalImage(imgPath, 430, FilterInvert)
 
 	txt := canvas.NewText(fmt.Sprintf("…%s has fallen…", name), color.White)
 	txt.TextSize = 34
@@ -225,200 +145,583 @@ func showSpookyAnimation(win fyne.Window, state *GameState, imgPath, name string
 
 	win.SetContent(NewClickInterceptor(container.NewMax(bg, container.NewCenter(body))))
 
-	go func() {
-		for _, size := range []float32{430, 520, 460, 560, 430} {
-			time.Sleep(300 * time.Millisecond)
-			img.SetMinSize(fyne.NewSize(size, size))
+	var frames []AnimationFrame
+	for _, size := range []float32{430, 520, 460, 560, 430} {
+		sz := size
+		frames = append(frames, AnimationFrame{Delay: 300 * time.Millisecond, Apply: func() {
+			img.SetMinSize(fyne.NewSize(sz, sz))
 			img.Refresh()
-		}
-		time.Sleep(600 * time.Millisecond)
-		after()
-	}()
+		}})
+	}
+	animations.Run(frames, 600*time.Millisecond, after)
 }
 
 // ===== SCREENS =====
 
-func createWinScreen(app fyne.App, win fyne.Window, state *GameState) fyne.CanvasObject {
+// showWinScreen records a won run in the museum, unless it was practice, and shows the
+// win screen.
+func showWinScreen(app fyne.App, win fyne.Window, state *engine.GameState) {
+	// Practice runs are unranked.
+	if !state.Rules.Practice {
+		if err := recordInMuseum(state); err != nil {
+			slog.Error("museum entry not recorded", "err", err)
+		}
+	}
+	win.SetContent(createWinScreen(app, win, state))
+}
 
-	// Play victory sound once
-	go func() {
-		// Small delay so UI loads first (prevents the thread warning)
-		time.Sleep(200 * time.Millisecond)
-		fyne.Do(func() {
-			PlaySoundEffect("sfx/victory.mp3")
-		})
+func createWinScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
 
-	}()
+	// The victory fanfare is the apex stinger, played when the winning attempt resolved.
 
-	finalScore := calculateScore(state)
+	finalScore := engine.CalculateScore(state)
+
+	heading := "👑 APEX PREDATOR REACHED 👑"
+	var ending fyne.CanvasObject = layout.NewSpacer()
+	if state.Rules.OutbreakPercent > 0 {
+		heading = fmt.Sprintf("🦠 OUTBREAK COMPLETE — %.0f%% INFECTED 🦠", engine.InfectedShare(state)*100)
+	} else if host := state.Animals[state.PlayerName]; host != nil {
+		e := engine.EndingOf(host)
+		if e.Title != "" {
+			heading = "👑 " + strings.ToUpper(e.Title) + " 👑"
+		}
+		if e.Text != "" {
+			ending = container.NewCenter(newFlavorText(state, e.Text))
+		}
+	}
 
-	title := canvas.NewText("👑 APEX PREDATOR REACHED 👑", color.White)
+	title := canvas.NewText(plain(heading), color.White)
 	title.TextSize = 40
 	title.Alignment = fyne.TextAlignCenter
 
-	cleanName := strings.TrimSpace(strings.ToValidUTF8(state.playerName, ""))
+	cleanName := strings.TrimSpace(strings.ToValidUTF8(state.PlayerName, ""))
 
-	info := canvas.NewText(fmt.Sprintf("Final Host: %s — Score: %d", cleanName, finalScore), color.White)
+	summary := func(score int) string {
+		s := fmt.Sprintf("Final Host: %s — Score: %d", cleanName, score)
+		if state.NGPlus > 0 {
+			s += fmt.Sprintf(" — NG+%d", state.NGPlus)
+		}
+		if bonus := engine.EndingBonus(state, state.Animals[state.PlayerName]); bonus != 0 {
+			s += fmt.Sprintf(" — ending %+d", bonus)
+		}
+		if d := state.Rules.Disclosure(); d != "" {
+			s += " (" + d + ")"
+		}
+		return s
+	}
+
+	info := canvas.NewText(summary(finalScore), color.White)
 	info.TextSize = 28
 	info.Alignment = fyne.TextAlignCenter
 
+	var medal fyne.CanvasObject = layout.NewSpacer()
+	if m := engine.MedalFor(state, finalScore); m != "" {
+		text := canvas.NewText(plain(m+" Medal"), color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff})
+		text.TextSize = 32
+		text.TextStyle = fyne.TextStyle{Bold: true}
+		text.Alignment = fyne.TextAlignCenter
+		medal = text
+	}
+
+	confetti := NewConfetti()
+	celebrate(confetti, finalScore, func(n int) {
+		info.Text = summary(n)
+		info.Refresh()
+	})
+
+	times := canvas.NewText(fmt.Sprintf("Game time: %ds — Wall time: %ds — Seed: %d", int(state.Stats.Clock.Elapsed().Seconds()), int(state.Stats.Clock.WallElapsed().Seconds()), state.Seed), color.White)
+	times.TextSize = 20
+	times.Alignment = fyne.TextAlignCenter
+
+	var bound fyne.CanvasObject = layout.NewSpacer()
+	if best, ok := scoreBound(state); ok && best.Score > 0 {
+		route := newLabel("Rough best route (ignores the food web and carriers): " + strings.Join(best.Route, " → "))
+		route.Hide()
+		reveal := newButton("Show rough best route", nil)
+		reveal.OnTapped = inputs.Bind("show-bound", func() {
+			route.Show()
+			reveal.Hide()
+		})
+		bound = container.NewVBox(
+			container.NewCenter(newLabel(fmt.Sprintf("You scored %.0f%% of the approximate best (%d)", float64(finalScore)*100/float64(best.Score), best.Score))),
+			container.NewCenter(reveal),
+			container.NewCenter(route),
+		)
+	}
+
 	return NewClickInterceptor(container.NewMax(
 		loadBackground(),
 		container.NewCenter(
 			container.NewVBox(
 				layout.NewSpacer(),
 				title,
+				ending,
 				info,
+				medal,
+				lineageLabel(state),
+				times,
+				bound,
+				fallenSummary(state),
+				container.NewCenter(newGamePlusControls(app, win, state)),
+				container.NewCenter(tagControls(state)),
+				container.NewCenter(container.NewHBox(exportButton(win, LoadRuns), conservationButton(state, win))),
 				layout.NewSpacer(),
 			),
 		),
+		confetti,
 	))
 }
 
-func createGameScreen(app fyne.App, win fyne.Window, state *GameState) fyne.CanvasObject {
+// fallenSummary lists every infected animal with its fallen (inverted) portrait. The
+// images are decoded on a worker so a big roster does not stall the win screen.
+func fallenSummary(state *engine.GameState) fyne.CanvasObject {
+	// Copy what the worker needs; it must not read the state itself.
+	type fallenAnimal struct {
+		name, image string
+		filter      ImageFilter
+	}
+	var fallen []fallenAnimal
+	for _, name := range engine.SortedAnimalNames(state) {
+		if a := state.Animals[name]; a.Infected {
+			fallen = append(fallen, fallenAnimal{a.Name, a.GetImagePath(), FilterInvert})
+		}
+	}
+	infected := len(fallen)
+	// Animals that died of natural causes are shown in grayscale after the infected.
+	for _, a := range state.Dead {
+		fallen = append(fallen, fallenAnimal{a.Name + " ⚰", a.GetImagePath(), FilterGrayscale})
+	}
+
+	loading := newLabel(fmt.Sprintf("Preparing summary of %d fallen…", len(fallen)))
+	gallery := container.NewGridWrap(fyne.NewSize(110, 130))
+	box := container.NewVBox(container.NewCenter(loading), container.NewCenter(gallery))
+
+	go func() {
+		for _, a := range fallen {
+			select {
+			case <-shutdown.Done():
+				return
+			default:
+			}
+			_, _ = images.Get(a.image, a.filter)
+		}
+		fyne.Do(func() {
+			for _, a := range fallen {
+				name := widget.NewLabelWithStyle(a.name, fyne.TextAlignCenter, fyne.TextStyle{})
+				name.Truncation = fyne.TextTruncateEllipsis
+				gallery.Add(container.NewBorder(nil, name, nil, nil, loadAnimalImage(a.image, 90, a.filter)))
+			}
+			text := fmt.Sprintf("%d fallen", infected)
+			if len(state.Dead) > 0 {
+				text += fmt.Sprintf(", %d died of natural causes", len(state.Dead))
+			}
+			loading.SetText(text)
+		})
+	}()
+	return box
+}
+
+func createLossScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
 
-	if state.timerStop != nil {
-		state.timerStop <- true
+	heading := "💀 OUT OF ATTEMPTS 💀"
+	if engine.AttemptsLeft(state) != 0 {
+		heading = "💀 NOWHERE LEFT TO SPREAD 💀"
 	}
-	state.timerStop = make(chan bool)
+	title := canvas.NewText(plain(heading), color.White)
+	title.TextSize = 40
+	title.Alignment = fyne.TextAlignCenter
 
-	timerText := canvas.NewText("⏱ 0s", color.White)
-	scoreText := canvas.NewText(fmt.Sprintf("Score: %d", calculateScore(state)), color.White)
+	host := state.Animals[state.PlayerName]
+	info := canvas.NewText(fmt.Sprintf("Final Host: %s (Level %d/%d) — Day %d", host.Name, host.Level, state.MaxLevel, state.CurrentDay), color.White)
+	info.TextSize = 28
+	info.Alignment = fyne.TextAlignCenter
+
+	times := canvas.NewText(fmt.Sprintf("Game time: %ds — Wall time: %ds — Seed: %d", int(state.Stats.Clock.Elapsed().Seconds()), int(state.Stats.Clock.WallElapsed().Seconds()), state.Seed), color.White)
+	times.TextSize = 20
+	times.Alignment = fyne.TextAlignCenter
+
+	retry := newButton("Try Again", inputs.Bind("retry", func() {
+		next := engine.NewGameState(state.MapPath)
+		next.Rules = state.Rules
+		engine.SeedRun(next, state.Seed)
+		win.SetContent(createStarterSelectionScreen(app, win, next))
+	}))
 
+	return NewClickInterceptor(container.NewMax(
+		loadBackground(),
+		container.NewCenter(
+			container.NewVBox(
+				layout.NewSpacer(),
+				title,
+				info,
+				lineageLabel(state),
+				times,
+				container.NewCenter(tagControls(state)),
+				container.NewCenter(container.NewHBox(retry, exportButton(win, LoadRuns), conservationButton(state, win))),
+				layout.NewSpacer(),
+			),
+		),
+	))
+}
+
+// stopGameTimer stops the ticker of the last game screen built; each game screen
+// replaces it with its own.
+var stopGameTimer context.CancelFunc = func() {}
+
+func createGameScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	if engine.Passing(state) {
+		return createPassScreen(app, win, state)
+	}
+	inputs.Reset()
+	redrawScreen = func() { win.SetContent(createGameScreen(app, win, state)) }
+	showRun(state)
+	autosave(state)
+
+	stopGameTimer()
+	ticking, stop := context.WithCancel(shutdown.Context())
+	stopGameTimer = stop
+	var screen fyne.CanvasObject
+
+	timerText := canvas.NewText(plain("⏱ 0s"), color.White)
+	scoreText := canvas.NewText(engine.ScoreLine(state), color.White)
+	projectionText := canvas.NewText(engine.ProjectionLine(state), color.White)
+	tierText := canvas.NewText(plain(engine.PenaltyTier(state)), color.White)
+
+	// The ticker only wakes the UI goroutine, which reads the state and updates the
+	// labels itself, and stops the ticker once the player has left this screen.
 	go func() {
+		tick := time.NewTicker(time.Second)
+		defer tick.Stop()
 		for {
 			select {
-			case <-state.timerStop:
+			case <-ticking.Done():
 				return
-			default:
-				time.Sleep(1 * time.Second)
-				timerText.Text = fmt.Sprintf("⏱ %ds", int(time.Since(state.stats.StartTime).Seconds()))
-				scoreText.Text = fmt.Sprintf("Score: %d", calculateScore(state))
-				timerText.Refresh()
-				scoreText.Refresh()
+			case <-tick.C:
+				fyne.Do(func() {
+					if win.Content() != screen {
+						stop()
+						return
+					}
+					timerText.Text = plain(fmt.Sprintf("⏱ %ds (wall %ds)", int(state.Stats.Clock.Elapsed().Seconds()), int(state.Stats.Clock.WallElapsed().Seconds())))
+					scoreText.Text = engine.ScoreLine(state)
+					projectionText.Text = engine.ProjectionLine(state)
+					tierText.Text = plain(engine.PenaltyTier(state))
+					timerText.Refresh()
+					scoreText.Refresh()
+					projectionText.Refresh()
+					tierText.Refresh()
+				})
 			}
 		}
 	}()
 
-	player := state.animals[state.playerName]
+	player := state.Animals[state.PlayerName]
+
+	wait := newButton("⏭ Wait", inputs.Bind("wait", func() {
+		engine.RememberUndo(state)
+		won, lost := engine.WaitOut(state)
+		engine.EndTurn(state, "")
+		switch {
+		case won:
+			exportRun(state)
+			showWinScreen(app, win, state)
+		case lost:
+			exportRun(state)
+			win.SetContent(createLossScreen(app, win, state))
+		default:
+			win.SetContent(createGameScreen(app, win, state))
+		}
+	}))
+
+	pause := newButton("⏸ Pause", inputs.Bind("pause", func() {
+		showPauseDialog(state, win)
+	}))
+
+	journal := newButton(fmt.Sprintf("📓 Journal (%d)", len(state.Journal)), inputs.Bind("journal", func() {
+		showJournal(state, win)
+	}))
+
+	burstText := fmt.Sprintf("🎰 Mutation Burst (chance ×%.0f)", engine.BurstChanceFactor)
+	if state.BurstArmed {
+		burstText = "🎰 Burst armed — tap to cancel"
+	}
+	burst := newButton(burstText, inputs.Bind("burst", func() {
+		if err := engine.ToggleBurst(state); err == nil {
+			win.SetContent(createGameScreen(app, win, state))
+		}
+	}))
+	if state.BurstUsed {
+		burst.SetText(plain("🎰 Burst used"))
+		burst.Disable()
+	}
+	inputs.HandlePrefix("note:", func(text string) { engine.AddJournalNote(state, text) })
+
+	season := engine.SeasonForDay(state.CurrentDay)
+
+	title := fmt.Sprintf("Day %d %s %s — %s (Level %d)", state.CurrentDay, state.Phase.Icon(), state.Phase, player.Name, player.Level)
+	if state.NGPlus > 0 {
+		title = fmt.Sprintf("NG+%d — %s", state.NGPlus, title)
+	}
+	if turn := engine.TurnLabel(state); turn != "" {
+		title = turn + " — " + title
+	}
+	if icons := engine.CurseIcons(state); icons != "" {
+		title += " " + icons
+	}
 
 	header := container.NewVBox(
-		container.NewCenter(widget.NewLabelWithStyle(fmt.Sprintf("Day %d — %s (Level %d)", state.currentDay, player.Name, player.Level), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
-		container.NewCenter(timerText),
-		container.NewCenter(scoreText),
+		container.NewCenter(container.NewHBox(virusSpriteImage(state),
+			widget.NewLabelWithStyle(plain(title), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))),
+		container.NewCenter(newLabel(fmt.Sprintf("%s %s — %d days until the season turns", season.Icon(), season, engine.DaysUntilNextSeason(state.CurrentDay)))),
+		container.NewCenter(newLabel("🧬 "+engine.LineageBreadcrumb(state))),
+		container.NewCenter(container.NewHBox(timerText, wait, pause, journal, burst)),
+		container.NewCenter(container.NewHBox(scoreText, tierText)),
+		container.NewCenter(projectionText),
 	)
+	if left := engine.AttemptsLeft(state); left >= 0 {
+		budget := canvas.NewText(plain(fmt.Sprintf("🎯 %d / %d attempts left", left, state.Rules.AttemptBudget)), color.White)
+		budget.TextSize = 24
+		budget.TextStyle = fyne.TextStyle{Bold: true}
+		if left <= 3 {
+			budget.Color = color.NRGBA{R: 0xff, G: 0x60, B: 0x40, A: 0xff}
+		}
+		header.Add(container.NewCenter(budget))
+	}
+	progress := widget.NewProgressBar()
+	progress.SetValue(engine.VictoryProgress(state))
+	header.Add(container.NewBorder(nil, nil, newLabel(state.Rules.VictoryDescription()), nil, progress))
 
-	var cards []fyne.CanvasObject
+	if len(state.DayReport) > 0 {
+		header.Add(container.NewCenter(newLabel(strings.Join(state.DayReport, " · "))))
+	}
+	if lines := engine.EffectLines(state); len(lines) > 0 {
+		header.Add(container.NewCenter(newLabel(strings.Join(lines, " · "))))
+	}
 
-	for _, target := range state.animals {
+	redraw := func() { win.SetContent(createGameScreen(app, win, state)) }
+	if app.Preferences().Bool(prefMapView) {
+		header.Add(container.NewCenter(mapViewToggle(app, redraw)))
+	} else {
+		header.Add(container.NewCenter(container.NewHBox(boardViewControls(app, redraw), mapViewToggle(app, redraw))))
+	}
+	if state.Rules.Practice {
+		header.Add(container.NewCenter(practiceControls(state, win, redraw)))
+	}
+	if state.Rules.PassiveSpread {
+		header.Add(container.NewCenter(priorityControls(state, win, redraw)))
+	}
+	if state.Rules.Mutations {
+		header.Add(container.NewCenter(mutationControls(state, win, redraw)))
+	}
+	header.Add(forecastPanel(state))
+	if banner := adviceBanner(state, redraw); banner != nil {
+		header.Add(banner)
+	}
+	mapView := app.Preferences().Bool(prefMapView)
+	listView := !mapView && app.Preferences().Bool(prefListView)
 
-		if target.Infected || (target.Level != player.Level && target.Level != player.Level+1) {
-			continue
-		}
+	var cards []boardCard
+	var rows []targetRow
+	var targets []*engine.Animal
 
-		img := loadAnimalImage(target.GetImagePath(), false, 160)
-		name := widget.NewLabel(target.Name)
+	for _, target := range engine.CandidateTargets(state) {
 
-		btn := widget.NewButton("INFECT", func(t *Animal) func() {
+		btn := newButton("INFECT", inputs.Bind("infect:"+target.Name, func(t *engine.Animal) func() {
 			return func() {
 
-				state.stats.Attempts++
-
-				if t.RedHerring {
-					PlaySoundEffect("sfx/fail.mp3")
-					info := state.redFacts[t.Name]
-					dialog.ShowInformation("🚫 RED HERRING", fmt.Sprintf("%s cannot be infected.\n🐾 %s\n📌 %s", t.Name, info.FunFact, info.Reason), win)
+				engine.RememberUndo(state)
+				out := engine.AttemptInfection(state, t)
+				if out.Kind != engine.OutcomeUnavailable {
+					engine.EndTurn(state, t.Name)
+				}
+				if out.Won || out.Lost {
+					exportRun(state)
+				}
+				if out.Won && out.Kind != engine.OutcomeInfected {
+					// Passive spread overnight took the outbreak over the line.
+					showWinScreen(app, win, state)
 					return
 				}
+				next := func() fyne.CanvasObject {
+					if out.Lost {
+						return createLossScreen(app, win, state)
+					}
+					return createGameScreen(app, win, state)
+				}
 
-				if rand.Float64() < t.InfectionRate*state.virus.Strength {
-					PlaySoundEffect("sfx/success.mp3")
-					t.Infected = true
-					state.currentDay++
-
-					if t.Level > player.Level {
-						state.stats.NextLevelInfections++
-					} else {
-						state.stats.SameLevelInfections++
+				switch out.Kind {
+				case engine.OutcomeRedHerring:
+					// Redraw first: a curse may have hidden the chances, and the
+					// attempts count has moved on.
+					win.SetContent(next())
+					info := state.RedFacts[t.Name]
+					msg := fmt.Sprintf("%s cannot be infected.\n🐾 %s\n📌 %s", t.Name, info.FunFact, info.Reason)
+					if out.Consequence != "" {
+						msg += "\n" + out.Consequence
 					}
+					showInformationContent(state, "🚫 RED HERRING", newFlavorText(state, msg), win)
 
-					showSpookyAnimation(win, state, t.GetImagePath(), t.Name, func() {
+				case engine.OutcomeDefenseBroken:
+					win.SetContent(next())
+					showInformation(state, "🛡 Defense Broken",
+						fmt.Sprintf("%s's %s is broken (%d/%d).", t.Name, out.Phase.Name, out.PhaseIndex+1, len(t.ResistancePhases)), win)
 
-						state.playerName = t.Name
+				case engine.OutcomeLatched:
+					win.SetContent(next())
+					showInformation(state, "🪝 Latched On",
+						fmt.Sprintf("The virus has latched onto %s. Take it over from tomorrow.", t.Name), win)
 
-						if t.Level == state.maxLevel {
-							win.SetContent(createWinScreen(app, win, state))
+				case engine.OutcomeInfected:
+					showSpookyAnimation(win, state, t.GetImagePath(), t.Name, func() {
+						if out.Won {
+							showWinScreen(app, win, state)
 							return
 						}
 
-						win.SetContent(createGameScreen(app, win, state))
+						win.SetContent(next())
+						if out.Crit != "" {
+							showInformation(state, "💥 Critical Success", out.Crit, win)
+						}
 					})
 
-					return
+				case engine.OutcomeResisted:
+					msg := t.Name + " resisted infection."
+					if out.Consequence != "" {
+						msg += "\n" + out.Consequence
+					}
+					if out.Crit != "" {
+						msg += "\n" + out.Crit
+					}
+					win.SetContent(next())
+					showInformation(state, "Failed", msg, win)
 				}
+			}
+		}(target)))
+
+		status := "Ready"
+		var defense fyne.CanvasObject = layout.NewSpacer()
+		if phase, i := engine.CurrentResistancePhase(state, target); phase != nil {
+			status = fmt.Sprintf("🛡 %s (%d/%d)", phase.Name, i+1, len(target.ResistancePhases))
+			defense = newLabel(status)
+			btn.SetText("BREAK DEFENSE")
+		} else if _, ok := engine.LatchedOn(state, target); ok {
+			status = "🪝 Latched"
+			defense = newLabel(status)
+			btn.SetText("TAKE OVER")
+		} else if state.Rules.TwoStage {
+			btn.SetText("LATCH")
+		}
 
-				PlaySoundEffect("sfx/fail.mp3")
-				dialog.ShowInformation("Failed", t.Name+" resisted infection.", win)
+		if reason := engine.BlockedReason(state, target); reason != "" {
+			status = reason
+			btn.SetText(plain(reason))
+			btn.Disable()
+		}
+
+		if listView {
+			row := targetRow{animal: target, chance: engine.InfectionChance(state, target), status: engine.WithMood(state, target, status), action: btn}
+			if engine.Blinded(state) {
+				row.chance = 0
 			}
-		}(target))
+			row.chanceText = engine.ChanceText(state, row.chance)
+			rows = append(rows, row)
+			continue
+		}
+
+		targets = append(targets, target)
+		cards = append(cards, func(t *engine.Animal) boardCard {
+			return func(featured bool) fyne.CanvasObject {
+				img := loadAnimalImage(t.GetImagePath(), portraitSize(featured), statusFilters(state, t)...)
+				name := newLabel(engine.WithMood(state, t, t.Name))
+
+				var portrait fyne.CanvasObject = img
+				if days, ok := engine.OnCooldown(state, t); ok {
+					img.Translucency = 0.6
+					badge := widget.NewLabelWithStyle(plain(fmt.Sprintf("⏳ %dd", days)), fyne.TextAlignTrailing, fyne.TextStyle{Bold: true})
+					portrait = container.NewStack(img, container.NewVBox(badge))
+				}
 
-		card := container.NewVBox(container.NewCenter(img), container.NewCenter(name), container.NewCenter(btn))
-		cards = append(cards, card)
+				card := container.NewVBox(container.NewCenter(portrait), container.NewCenter(name), container.NewCenter(defense), container.NewCenter(scoutedNote(state, t)))
+				if featured {
+					card.Add(featuredDetail(t))
+					card.Add(container.NewCenter(newLabel("Chance: " + engine.ChanceText(state, engine.InfectionChance(state, t)))))
+				}
+				card.Add(container.NewCenter(btn))
+				return NewInfoCard(card, win, func() string { return cardInfo(state, t) })
+			}
+		}(target))
 	}
 
-	grid := container.NewGridWithColumns(3, cards...)
+	var board fyne.CanvasObject
+	switch {
+	case mapView:
+		board = parkMap(state, targets, cards, gridColumns(app), redraw)
+	case listView:
+		board = targetTable(app, rows, redraw)
+	default:
+		board = adaptiveBoard(gridColumns(app), cards)
+	}
 
-	return NewClickInterceptor(container.NewMax(loadBackground(),
-		container.NewBorder(header, nil, nil, nil, container.NewScroll(grid))))
+	screen = NewClickInterceptor(container.NewMax(loadBackground(),
+		container.NewBorder(header, nil, nil, nil, container.NewScroll(board))))
+	return screen
 }
 
-func createStarterSelectionScreen(app fyne.App, win fyne.Window, state *GameState) fyne.CanvasObject {
-	var cards []fyne.CanvasObject
+func createStarterSelectionScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = func() { win.SetContent(createStarterSelectionScreen(app, win, state)) }
+	showRun(state)
+	var cards []boardCard
 
-	for _, a := range state.animals {
-		if a.Level != 1 {
-			continue
-		}
+	for _, a := range engine.StarterCandidates(state) {
 
-		img := loadAnimalImage(a.GetImagePath(), false, 160)
-		name := widget.NewLabel(a.Name)
-
-		btn := widget.NewButton("Choose", func(an *Animal) func() {
+		btn := newButton("Choose", inputs.Bind("choose:"+a.Name, func(an *engine.Animal) func() {
 			return func() {
 
-				if an.RedHerring {
+				if err := engine.ChooseStarter(state, an); err != nil {
 					PlaySoundEffect("sfx/fail.mp3")
-					info := state.redFacts[an.Name]
-					dialog.ShowInformation("🚫 Cannot Start Here",
-						fmt.Sprintf("%s cannot be patient zero.\n🐾 %s\n📌 %s", an.Name, info.FunFact, info.Reason), win)
+					info := state.RedFacts[an.Name]
+					showInformationContent(state, "🚫 Cannot Start Here",
+						newFlavorText(state, fmt.Sprintf("%s cannot be patient zero.\n🐾 %s\n📌 %s", an.Name, info.FunFact, info.Reason)), win)
 					return
 				}
 
 				PlaySoundEffect("sfx/success.mp3")
 
-				state.playerName = an.Name
-				an.Infected = true
-				state.stats.StartTime = time.Now()
-
 				win.SetContent(createGameScreen(app, win, state))
 			}
-		}(a))
+		}(a)))
 
-		card := container.NewVBox(container.NewCenter(img), container.NewCenter(name), container.NewCenter(btn))
-		cards = append(cards, card)
+		cards = append(cards, func(featured bool) fyne.CanvasObject {
+			card := container.NewVBox(container.NewCenter(loadAnimalImage(a.GetImagePath(), portraitSize(featured))), container.NewCenter(newLabel(a.Name)))
+			if featured {
+				card.Add(featuredDetail(a))
+			}
+			card.Add(container.NewCenter(btn))
+			return card
+		})
 	}
 
 	return NewClickInterceptor(container.NewMax(loadBackground(),
 		container.NewBorder(widget.NewLabelWithStyle("Choose Your Patient Zero", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
-			nil, nil, nil, container.NewScroll(container.NewGridWithColumns(3, cards...)))))
+			nil, nil, nil, container.NewScroll(adaptiveBoard(3, cards)))))
 }
 
-func createIntroScreen(app fyne.App, win fyne.Window, state *GameState) fyne.CanvasObject {
+func createIntroScreen(app fyne.App, win fyne.Window, state *engine.GameState) fyne.CanvasObject {
+	inputs.Reset()
+	redrawScreen = nil
+	showRun(state)
 
-	title := widget.NewLabelWithStyle("🦠 YELLOWSTONE OUTBREAK 🦠",
+	title := widget.NewLabelWithStyle(plain("🦠 YELLOWSTONE OUTBREAK 🦠"),
 		fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
 
-	sub := widget.NewLabel(
+	sub := newLabel(
 		"Deep beneath the soil of Yellowstone National Park, something ancient awakens.\n" +
 			"For millennia, it has slept. Dormant, waiting.\n\n" +
 			"But now, conditions are perfect.\n" +
@@ -434,40 +737,429 @@ func createIntroScreen(app fyne.App, win fyne.Window, state *GameState) fyne.Can
 
 	sub.Alignment = fyne.TextAlignCenter
 
-	start := widget.NewButton("Begin Infection", func() {
-		win.SetContent(createStarterSelectionScreen(app, win, state))
+	// The estimate is created once the buttons it holds exist.
+	refreshEstimate := func() {}
+
+	mercy := widget.NewCheck(fmt.Sprintf("Mercy rule: your first infection attempt always succeeds (score ×%.2f)", engine.MercyScoreMultiplier), func(on bool) {
+		state.Rules.MercyRule = on
+		inputs.Record(fmt.Sprintf("mercy:%t", on))
+		refreshEstimate()
+	})
+	mercy.SetChecked(state.Rules.MercyRule)
+	inputs.Handle("mercy:true", func() { mercy.SetChecked(true) })
+	inputs.Handle("mercy:false", func() { mercy.SetChecked(false) })
+
+	wild := widget.NewCheck("Wild genetics: animal stats vary from run to run", func(on bool) {
+		state.Rules.WildGenetics = on
+		inputs.Record(fmt.Sprintf("wild:%t", on))
+		refreshEstimate()
+	})
+	wild.SetChecked(state.Rules.WildGenetics)
+	inputs.Handle("wild:true", func() { wild.SetChecked(true) })
+	inputs.Handle("wild:false", func() { wild.SetChecked(false) })
+
+	cooldown := widget.NewCheck("Retry cooldown: a target that resists cannot be retried until tomorrow", func(on bool) {
+		state.Rules.RetryCooldown = on
+		inputs.Record(fmt.Sprintf("cooldown:%t", on))
+		refreshEstimate()
+	})
+	cooldown.SetChecked(state.Rules.RetryCooldown)
+	inputs.Handle("cooldown:true", func() { cooldown.SetChecked(true) })
+	inputs.Handle("cooldown:false", func() { cooldown.SetChecked(false) })
+
+	contacts := widget.NewCheck("Contact graph: close relationships spread the virus more easily", func(on bool) {
+		state.Rules.ContactWeighted = on
+		inputs.Record(fmt.Sprintf("contacts:%t", on))
+		refreshEstimate()
+	})
+	contacts.SetChecked(state.Rules.ContactWeighted)
+	inputs.Handle("contacts:true", func() { contacts.SetChecked(true) })
+	inputs.Handle("contacts:false", func() { contacts.SetChecked(false) })
+
+	twoStage := widget.NewCheck("Two-stage infection: latch onto a host, then take it over the next day", func(on bool) {
+		state.Rules.TwoStage = on
+		inputs.Record(fmt.Sprintf("twostage:%t", on))
+		refreshEstimate()
+	})
+	twoStage.SetChecked(state.Rules.TwoStage)
+	inputs.Handle("twostage:true", func() { twoStage.SetChecked(true) })
+	inputs.Handle("twostage:false", func() { twoStage.SetChecked(false) })
+
+	passiveSpread := widget.NewCheck("Passive spread: the virus spreads along contacts each morning", func(on bool) {
+		state.Rules.PassiveSpread = on
+		inputs.Record(fmt.Sprintf("spread:%t", on))
+		refreshEstimate()
+	})
+	passiveSpread.SetChecked(state.Rules.PassiveSpread)
+	inputs.Handle("spread:true", func() { passiveSpread.SetChecked(true) })
+	inputs.Handle("spread:false", func() { passiveSpread.SetChecked(false) })
+
+	mutations := widget.NewCheck("Mutations: infections earn points for virus upgrades", func(on bool) {
+		state.Rules.Mutations = on
+		inputs.Record(fmt.Sprintf("mutations:%t", on))
+		refreshEstimate()
+	})
+	mutations.SetChecked(state.Rules.Mutations)
+	inputs.Handle("mutations:true", func() { mutations.SetChecked(true) })
+	inputs.Handle("mutations:false", func() { mutations.SetChecked(false) })
+
+	immunity := widget.NewCheck("Immune response: infected animals may recover and become immune", func(on bool) {
+		state.Rules.Immunity = on
+		inputs.Record(fmt.Sprintf("immunity:%t", on))
+		refreshEstimate()
+	})
+	immunity.SetChecked(state.Rules.Immunity)
+	inputs.Handle("immunity:true", func() { immunity.SetChecked(true) })
+	inputs.Handle("immunity:false", func() { immunity.SetChecked(false) })
+
+	cursed := widget.NewCheck("Curses: attempting a red herring curses the virus", func(on bool) {
+		state.Rules.Curses = on
+		inputs.Record(fmt.Sprintf("curses:%t", on))
+		refreshEstimate()
+	})
+	cursed.SetChecked(state.Rules.Curses)
+	inputs.Handle("curses:true", func() { cursed.SetChecked(true) })
+	inputs.Handle("curses:false", func() { cursed.SetChecked(false) })
+
+	thisWeek := weeklyFor(time.Now())
+	weekly := widget.NewCheck(fmt.Sprintf("Weekly mutation %s — %s", thisWeek.Label(), thisWeek.Description), func(on bool) {
+		state.Rules.Weekly = engine.WeeklyMutation{}
+		if on {
+			state.Rules.Weekly = thisWeek
+		}
+		inputs.Record(fmt.Sprintf("weekly:%t", on))
+		refreshEstimate()
+	})
+	weekly.SetChecked(state.Rules.Weekly.Active())
+	inputs.Handle("weekly:true", func() { weekly.SetChecked(true) })
+	inputs.Handle("weekly:false", func() { weekly.SetChecked(false) })
+	weeklyBoardButton := newButton("🏆 Weekly board", inputs.Bind("weekly-board", func() {
+		showWeeklyBoard(thisWeek, win)
+	}))
+
+	practice := widget.NewCheck("Practice: undo moves and save branch points (unranked)", func(on bool) {
+		state.Rules.Practice = on
+		inputs.Record(fmt.Sprintf("practice:%t", on))
+		refreshEstimate()
+	})
+	practice.SetChecked(state.Rules.Practice)
+	inputs.Handle("practice:true", func() { practice.SetChecked(true) })
+	inputs.Handle("practice:false", func() { practice.SetChecked(false) })
+
+	// The handlers are attached after the initial state so lite mode's forced values
+	// are shown without being saved as the player's preference.
+	reducedMotion := widget.NewCheck("Reduced motion: no pulsing or flashing effects", nil)
+	reducedMotion.SetChecked(animations.ReducedMotion())
+	reducedMotion.OnChanged = func(on bool) {
+		animations.SetReducedMotion(on)
+		app.Preferences().SetBool(prefReducedMotion, on)
+	}
+
+	captionsCheck := widget.NewCheck("Captions: describe sounds on screen", func(on bool) {
+		captions.SetEnabled(on)
+		app.Preferences().SetBool(prefCaptions, on)
+	})
+	captionsCheck.SetChecked(captions.Enabled())
+
+	ambientCheck := widget.NewCheck("Ambient effects: drifting spores and parallax (turn off on slow machines)", nil)
+	ambientCheck.SetChecked(ambient.Enabled() && !liteMode)
+	ambientCheck.OnChanged = func(on bool) {
+		ambient.SetEnabled(on)
+		app.Preferences().SetBool(prefAmbientOff, !on)
+	}
+	if liteMode {
+		reducedMotion.Disable()
+		ambientCheck.Disable()
+	}
+
+	emojiCheck := widget.NewCheck("Emoji-free text: use icons instead of emoji", nil)
+	emojiCheck.SetChecked(noEmoji)
+	emojiCheck.OnChanged = func(on bool) {
+		noEmoji = on
+		app.Preferences().SetBool(prefNoEmoji, on)
+		win.SetContent(createIntroScreen(app, win, state))
+	}
+
+	seedEntry := widget.NewEntry()
+	seedEntry.SetText(strconv.FormatInt(state.Seed, 10))
+	seedEntry.OnSubmitted = func(text string) {
+		seed, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil || seed == 0 {
+			seedEntry.SetText(strconv.FormatInt(state.Seed, 10))
+			return
+		}
+		engine.SeedRun(state, seed)
+		inputs.Record(fmt.Sprintf("run-seed:%d", seed))
+	}
+	inputs.HandlePrefix("run-seed:", func(arg string) {
+		if seed, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			seedEntry.SetText(arg)
+			engine.SeedRun(state, seed)
+		}
 	})
 
+	victoryOptions := []string{engine.RulesConfig{}.VictoryDescription()}
+	for _, pct := range engine.OutbreakOptions {
+		victoryOptions = append(victoryOptions, engine.RulesConfig{OutbreakPercent: pct}.VictoryDescription())
+	}
+	victory := widget.NewSelect(victoryOptions, nil)
+	victory.SetSelected(state.Rules.VictoryDescription())
+	victory.OnChanged = func(choice string) {
+		state.Rules.OutbreakPercent = 0
+		for _, pct := range engine.OutbreakOptions {
+			if choice == (engine.RulesConfig{OutbreakPercent: pct}).VictoryDescription() {
+				state.Rules.OutbreakPercent = pct
+			}
+		}
+		inputs.Record("victory:" + choice)
+		refreshEstimate()
+	}
+	for _, opt := range victoryOptions {
+		choice := opt
+		inputs.Handle("victory:"+choice, func() { victory.SetSelected(choice) })
+	}
+
+	budgetOptions := []string{engine.RulesConfig{}.BudgetDescription()}
+	for _, n := range engine.BudgetOptions {
+		budgetOptions = append(budgetOptions, engine.RulesConfig{AttemptBudget: n}.BudgetDescription())
+	}
+	budget := widget.NewSelect(budgetOptions, nil)
+	budget.SetSelected(state.Rules.BudgetDescription())
+	budget.OnChanged = func(choice string) {
+		state.Rules.AttemptBudget = 0
+		for _, n := range engine.BudgetOptions {
+			if choice == (engine.RulesConfig{AttemptBudget: n}).BudgetDescription() {
+				state.Rules.AttemptBudget = n
+			}
+		}
+		inputs.Record("budget:" + choice)
+		refreshEstimate()
+	}
+	for _, opt := range budgetOptions {
+		choice := opt
+		inputs.Handle("budget:"+choice, func() { budget.SetSelected(choice) })
+	}
+
+	var playerOptions []string
+	for _, n := range engine.PlayerOptions {
+		playerOptions = append(playerOptions, engine.PlayersDescription(n))
+	}
+	players := widget.NewSelect(playerOptions, nil)
+	players.SetSelected(engine.PlayersDescription(state.Rules.Players))
+	players.OnChanged = func(choice string) {
+		state.Rules.Players = 0
+		for _, n := range engine.PlayerOptions {
+			if choice == engine.PlayersDescription(n) && n > 1 {
+				state.Rules.Players = n
+			}
+		}
+		inputs.Record("players:" + choice)
+	}
+	for _, opt := range playerOptions {
+		choice := opt
+		inputs.Handle("players:"+choice, func() { players.SetSelected(choice) })
+	}
+
+	start := newButton("Begin Infection", inputs.Bind("begin", func() {
+		if err := engine.CheckStarters(state); err != nil {
+			win.SetContent(createMapErrorScreen(app, win, state, err))
+			return
+		}
+		win.SetContent(createStarterSelectionScreen(app, win, state))
+	}))
+	museum := newButton("🏛 Museum", inputs.Bind("museum", func() {
+		win.SetContent(createMuseumScreen(app, win, state))
+	}))
+	stats := newButton("📊 Stats", inputs.Bind("stats", func() {
+		win.SetContent(createStatsScreen(app, win, state))
+	}))
+	ecosystems := newButton("🌍 Ecosystem: "+ecosystemOf(state.MapPath, state.MapConfig).Name, inputs.Bind("ecosystems", func() {
+		win.SetContent(createEcosystemScreen(app, win, state))
+	}))
+	highScores := newButton("🏆 High Scores", inputs.Bind("high-scores", func() {
+		win.SetContent(createHighScoresScreen(app, win, state))
+	}))
+	replays := newButton("🎞 Replays", inputs.Bind("replays", func() {
+		win.SetContent(createReplaysScreen(app, win, state))
+	}))
+	rules := newButton("📜 Rules", inputs.Bind("rules", func() {
+		win.SetContent(createRulesScreen(app, win, state))
+	}))
+	reverse := newButton("🛡 Reverse Mode", inputs.Bind("reverse", func() {
+		rs := engine.NewGameState(state.MapPath)
+		rs.Rules = state.Rules
+		rs.Rules.Practice = false
+		r, err := newReverseRun(rs)
+		if err != nil {
+			win.SetContent(createMapErrorScreen(app, win, state, err))
+			return
+		}
+		win.SetContent(createReverseScreen(app, win, state, r))
+	}))
+	seeds := newButton("🎲 Choose a Seed", inputs.Bind("seeds", func() {
+		if err := engine.CheckStarters(state); err != nil {
+			win.SetContent(createMapErrorScreen(app, win, state, err))
+			return
+		}
+		win.SetContent(createSeedBrowserScreen(app, win, state))
+	}))
+	resume := newButton("▶ Continue", inputs.Bind("continue", func() {
+		next, err := LoadGame()
+		if err != nil {
+			showInformation(state, "Cannot Continue", err.Error(), win)
+			return
+		}
+		win.SetMainMenu(mainMenu(app, win, next))
+		win.SetContent(createGameScreen(app, win, next))
+	}))
+	if !HasSave() {
+		resume.Hide()
+	}
+	estimate, refresh := runLengthLabel(state)
+	refreshEstimate = refresh
+
 	return NewClickInterceptor(container.NewMax(
 		loadBackground(),
-		container.NewCenter(container.NewVBox(layout.NewSpacer(), title, sub, layout.NewSpacer(), start, layout.NewSpacer())),
+		container.NewCenter(container.NewVBox(layout.NewSpacer(), title, sub, layout.NewSpacer(), container.NewCenter(loadoutControls(app, win, state)), container.NewCenter(recommendationPanel(state, func() { win.SetContent(createIntroScreen(app, win, state)) })), container.NewCenter(estimate), container.NewCenter(container.NewHBox(newLabel("Victory:"), victory)), container.NewCenter(container.NewHBox(newLabel("Budget:"), budget)), container.NewCenter(container.NewHBox(newLabel("Players:"), players)), container.NewCenter(container.NewHBox(newLabel("Seed:"), container.NewGridWrap(fyne.NewSize(220, seedEntry.MinSize().Height), seedEntry))), container.NewCenter(mercy), container.NewCenter(wild), container.NewCenter(cooldown), container.NewCenter(contacts), container.NewCenter(twoStage), container.NewCenter(passiveSpread), container.NewCenter(mutations), container.NewCenter(immunity), container.NewCenter(cursed), container.NewCenter(container.NewHBox(weekly, weeklyBoardButton)), container.NewCenter(practice), container.NewCenter(reducedMotion), container.NewCenter(captionsCheck), container.NewCenter(ambientCheck), container.NewCenter(emojiCheck), ecosystems, resume, start, seeds, reverse, museum, highScores, replays, stats, rules, layout.NewSpacer())),
 	))
 }
 
 // ===== MAIN =====
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	os.Exit(runCommand(os.Args[1:]))
+}
 
-	application := app.New()
-	win := application.NewWindow("🦠 Yellowstone Outbreak")
-	win.Resize(fyne.NewSize(1200, 800))
+// runGUI implements the "gui" subcommand, the default when no command is given.
+func runGUI(args []string) int {
+	fs := flag.NewFlagSet("gui", flag.ContinueOnError)
+	recordInput := fs.String("record-input", "", "record user inputs with timestamps to this file")
+	replayInput := fs.String("replay-input", "", "replay user inputs recorded with --record-input")
+	dev := fs.Bool("dev", false, "developer mode: show the developer HUD and hot-reload the map, facts and images when they change on disk")
+	locale := fs.String("locale", "", "override the system locale, e.g. ja or ar-EG")
+	lite := fs.Bool("lite", false, "lite mode for slow machines: text-only cards, no images, animations or audio")
+	dashboard := fs.Bool("dashboard", false, "mirror the day, attempts and evolutions to this terminal while the window runs")
+	batch := fs.String("batch", "", "play a scripted sequence of actions from this file (- for stdin) without a window; same as the cli command")
+	seedFlag := fs.Int64("seed", 0, "seed the run so it rolls the same way every time (default: time-based)")
+	rulesFromFlags := ruleFlags(fs)
+	setupLogging := logFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
 
-	animals, max := LoadAnimalsFromJSON("yellowstone_animals.json")
+	mapPath := initDataDirs()
+	// Taken before the log file creates the config directory.
+	created := dataDirs.Missing()
+	setupLogging()
 
-	state := &GameState{
-		animals:  animals,
-		maxLevel: max,
-		virus: &Virus{
-			Modes:    []string{"Bite"},
-			Strength: 1.0,
-		},
-		redFacts: LoadRedHerringFacts("red_herring_facts.json"),
-		stats:    Stats{StartTime: time.Now()},
+	seed := *seedFlag
+	if seed == 0 {
+		seed = time.Now().UnixNano()
 	}
 
+	var replay *InputLog
+	if *replayInput != "" {
+		log, err := LoadInputLog(*replayInput)
+		if err != nil {
+			slog.Error("replay unreadable", "err", err)
+			return 1
+		}
+		if stale := log.Stamp.Mismatch(engine.CurrentStamp(mapPath)); stale != "" {
+			slog.Warn("stale replay", "recorded_with", stale)
+		}
+		replay = log
+		seed = log.Seed
+	}
+
+	rules, err := rulesFromFlags()
+	if err != nil {
+		slog.Error("invalid rules", "err", err)
+		return 1
+	}
+
+	if *batch != "" {
+		return runBatch(*batch, seed, mapPath, rules)
+	}
+
+	if *recordInput != "" {
+		if err := inputs.StartRecording(*recordInput, seed, engine.CurrentStamp(mapPath)); err != nil {
+			slog.Error("input recording failed", "err", err)
+			return 1
+		}
+		shutdown.OnShutdown("input recording", inputs.StopRecording)
+	}
+
+	liteMode = *lite
+
+	if err := dataDirs.Create(); err != nil {
+		slog.Error("data directories not created", "err", err)
+		created = nil
+	}
+
+	application := app.NewWithID("io.github.anaymody.rawr")
+	animations.SetReducedMotion(application.Preferences().Bool(prefReducedMotion))
+	captions.SetEnabled(application.Preferences().Bool(prefCaptions))
+	ambient.SetEnabled(!application.Preferences().Bool(prefAmbientOff))
+	noEmoji = application.Preferences().Bool(prefNoEmoji)
+	if *locale != "" {
+		activeLocale = fyne.Locale(*locale)
+	}
+	application.Settings().SetTheme(newLocaleTheme(LoadThemeConfig(dataDirs.Find(dataDirs.Config, themeConfigPath)), activeLocale))
+	win := application.NewWindow("🦠 Yellowstone Outbreak")
+	win.Resize(fyne.NewSize(1200, 800))
+	captions.Attach(win)
+	attachStingers()
+	attachWebhooks()
+	go loadWeeklyFeed()
+	if *dashboard {
+		attachDashboard()
+	}
+
+	state := engine.NewGameState(mapPath)
+	state.Rules = rules
+	engine.SeedRun(state, seed)
+	useEcosystem(state)
+	win.SetMainMenu(mainMenu(application, win, state))
+
 	_ = PlayMusicLoop("music/background.mp3")
+	shutdown.OnShutdown("audio", StopAudio)
+	audio.Preload("sfx/click.mp3", defaultSuccessSound, defaultFailSound, "sfx/victory.mp3")
+	audio.PreloadAnimals(state.Animals)
+
+	win.SetOnClosed(shutdown.Shutdown)
+	shutdown.HandleSignals(func() {
+		fyne.Do(func() {
+			shutdown.Shutdown()
+			application.Quit()
+		})
+	})
 
-	win.SetContent(createIntroScreen(application, win, state))
+	win.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if ev.Name == fyne.KeyF3 {
+			devHUD.Toggle()
+		}
+	})
+
+	if *dev {
+		devHUD.SetEnabled(true)
+		if err := WatchForReload(state); err != nil {
+			slog.Warn("dev reload disabled", "err", err)
+		}
+	}
+
+	if err := engine.CheckStarters(state); err != nil {
+		win.SetContent(createMapErrorScreen(application, win, state, err))
+	} else if len(created) > 0 && replay == nil {
+		win.SetContent(createWelcomeScreen(application, win, state, created))
+	} else if len(scanEcosystems()) > 1 {
+		win.SetContent(createEcosystemScreen(application, win, state))
+	} else {
+		win.SetContent(createIntroScreen(application, win, state))
+	}
+	if replay != nil {
+		inputs.Replay(replay)
+	}
 	win.ShowAndRun()
+	shutdown.Shutdown()
+	return 0
 }